@@ -152,6 +152,9 @@ func Style(style TextStyle, v ...interface{}) string {
 
 // Styles formats the text with the specified text styles
 func Styles(styles []TextStyle, v ...interface{}) string {
+	if !Enabled() {
+		return joinArgs(v)
+	}
 	styleCodes := make([]string, len(styles))
 	for i, style := range styles {
 		styleCodes[i] = fmt.Sprintf("\033[%dm", style)
@@ -168,6 +171,9 @@ func Styles(styles []TextStyle, v ...interface{}) string {
 
 // ColorAndStyle formats the text with both color and style
 func ColorAndStyle(fg ColorCode, bg ColorCode, style TextStyle, v ...interface{}) string {
+	if !Enabled() {
+		return joinArgs(v)
+	}
 	fgCode := OneForeground(fg)
 	bgCode := OneBackground(bg)
 
@@ -222,8 +228,48 @@ func ColorFrom(item uint64) ColorCode {
 	return colors[index]
 }
 
+// hashPalette holds the colors ColorFromHash chooses from, ANSI and RGB, excluding black
+var hashPalette = []ColorCode{
+	Red, Green, Yellow, Blue, Magenta, Cyan, White,
+	BrightRed, BrightGreen, BrightYellow, BrightBlue, BrightMagenta, BrightCyan, BrightWhite,
+	ColorOrange, ColorPink, ColorPurple, ColorTeal, ColorLimeGreen, ColorIndigo,
+	CreateRGB(139, 69, 19), CreateRGB(70, 130, 180), CreateRGB(218, 165, 32), CreateRGB(199, 21, 133),
+	CreateRGB(46, 139, 87), CreateRGB(106, 90, 205), CreateRGB(240, 128, 128), CreateRGB(0, 191, 255),
+}
+
+// ColorFromHash returns a color chosen by hashing item over a palette that includes RGB colors.
+// Unlike ColorFrom, consecutive values such as sequential ids are spread across the palette.
+func ColorFromHash(item uint64) ColorCode {
+	// splitmix64 finalizer
+	item ^= item >> 30
+	item *= 0xbf58476d1ce4e5b9
+	item ^= item >> 27
+	item *= 0x94d049bb133111eb
+	item ^= item >> 31
+	return hashPalette[item%uint64(len(hashPalette))]
+}
+
+// ColorFromString returns a stable color for s, see ColorFromHash.
+func ColorFromString(s string) ColorCode {
+	// FNV-1a
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return ColorFromHash(h)
+}
+
+// Tag formats the text on the given background with a readable foreground chosen by GetContrast.
+func Tag(bg ColorCode, v ...interface{}) string {
+	return Color(bg.GetContrast(), bg, v...)
+}
+
 // Color formats the given text with the specified foreground and background colors.
 func Color(fg, bg ColorCode, v ...interface{}) string {
+	if !Enabled() {
+		return joinArgs(v)
+	}
 	fgCode := OneForeground(fg)
 	bgCode := OneBackground(bg)
 	reset := Reset()
@@ -237,6 +283,9 @@ func Color(fg, bg ColorCode, v ...interface{}) string {
 
 // Foreground formats the given text with the specified foreground color.
 func Foreground(fg ColorCode, v ...interface{}) string {
+	if !Enabled() {
+		return joinArgs(v)
+	}
 	fgCode := OneForeground(fg)
 	reset := Reset()
 	args := make([]string, len(v))
@@ -249,6 +298,9 @@ func Foreground(fg ColorCode, v ...interface{}) string {
 
 // OneForeground returns the ANSI escape sequence for the given color code.
 func OneForeground(code ColorCode) string {
+	if !Enabled() {
+		return ""
+	}
 	if code.IsRGB() {
 		r := (code >> 24) & 0xFF
 		g := (code >> 16) & 0xFF
@@ -260,6 +312,9 @@ func OneForeground(code ColorCode) string {
 
 // Background formats the given text with the specified background color.
 func Background(code ColorCode, v ...interface{}) string {
+	if !Enabled() {
+		return joinArgs(v)
+	}
 	bgCode := OneBackground(code)
 	reset := Reset()
 	args := make([]string, len(v))
@@ -272,6 +327,9 @@ func Background(code ColorCode, v ...interface{}) string {
 
 // OneBackground returns the ANSI escape sequence for the given background color code.
 func OneBackground(code ColorCode) string {
+	if !Enabled() {
+		return ""
+	}
 	if code.IsRGB() {
 		r := (code >> 24) & 0xFF
 		g := (code >> 16) & 0xFF
@@ -283,5 +341,48 @@ func OneBackground(code ColorCode) string {
 
 // Reset returns the ANSI escape sequence to reset the text color.
 func Reset() string {
+	if !Enabled() {
+		return ""
+	}
 	return "\033[0m"
 }
+
+// Strip removes ANSI escape sequences from s, returning the plain text.
+func Strip(s string) string {
+	if strings.IndexByte(s, '\033') < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\033' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 >= len(s) {
+			break
+		}
+		switch s[i+1] {
+		case '[':
+			// CSI: parameters and intermediates end with a final byte in 0x40-0x7E
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7E) {
+				i++
+			}
+		case ']':
+			// OSC: terminated by BEL or ST (ESC \)
+			i += 2
+			for i < len(s) && s[i] != '\007' && !(s[i] == '\033' && i+1 < len(s) && s[i+1] == '\\') {
+				i++
+			}
+			if i < len(s) && s[i] == '\033' {
+				i++
+			}
+		default:
+			// Two byte escape sequence
+			i++
+		}
+	}
+	return b.String()
+}
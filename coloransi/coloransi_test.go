@@ -1,10 +1,18 @@
 package coloransi
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"testing"
 )
 
+// TestMain enables color so the escape sequence tests pass regardless of NO_COLOR
+func TestMain(m *testing.M) {
+	SetEnabled(true)
+	os.Exit(m.Run())
+}
+
 func TestTextStyles(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -321,3 +329,111 @@ func TestColorVisual(t *testing.T) {
 	fmt.Println(Foreground(ColorLimeGreen, "Lime Green Text"))
 	fmt.Println(Foreground(ColorIndigo, "Indigo Text"))
 }
+
+func TestStrip(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Plain", "plain text", "plain text"},
+		{"Color", Color(BrightWhite, Blue, "global"), "global"},
+		{"RGB and Style", ColorAndStyle(CreateRGB(100, 150, 200), Black, Bold, "RGB"), "RGB"},
+		{"Mixed", "a " + Foreground(Red, "b") + " c", "a b c"},
+		{"OSC", "\033]0;title\007text", "text"},
+		{"Truncated", "text\033", "text"},
+		{"Unicode", Foreground(Green, "🚀 ok"), "🚀 ok"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := Strip(tc.input); result != tc.expected {
+				t.Errorf("Expected %q, but got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestColorFromHash(t *testing.T) {
+	seen := make(map[ColorCode]bool)
+	for i := uint64(0); i < 1000; i++ {
+		c := ColorFromHash(i)
+		if c != ColorFromHash(i) {
+			t.Fatalf("ColorFromHash(%d) is not stable", i)
+		}
+		if c == Black {
+			t.Fatalf("ColorFromHash(%d) returned black", i)
+		}
+		seen[c] = true
+	}
+	if len(seen) != len(hashPalette) {
+		t.Errorf("Expected all %d palette colors to be used, got %d", len(hashPalette), len(seen))
+	}
+	if ColorFromString("request-1") != ColorFromString("request-1") {
+		t.Error("ColorFromString is not stable")
+	}
+}
+
+func TestTag(t *testing.T) {
+	expected := "\033[30m\033[103mid\033[0m"
+	if got := Tag(BrightYellow, "id"); got != expected {
+		t.Errorf("Tag() = %q, want %q", got, expected)
+	}
+	expected = "\033[38;2;255;255;255m\033[44mid\033[0m"
+	if got := Tag(Blue, "id"); got != expected {
+		t.Errorf("Tag() = %q, want %q", got, expected)
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	SetEnabled(false)
+	defer SetEnabled(true)
+
+	if got := Color(Red, Blue, "plain", 1); got != "plain 1" {
+		t.Errorf("Color() with color disabled = %q", got)
+	}
+	if got := ColorAndStyle(Red, Blue, Bold, "plain"); got != "plain" {
+		t.Errorf("ColorAndStyle() with color disabled = %q", got)
+	}
+	if got := Style(Bold, "plain"); got != "plain" {
+		t.Errorf("Style() with color disabled = %q", got)
+	}
+	if got := OneForeground(Red) + OneBackground(Red) + Reset(); got != "" {
+		t.Errorf("Expected no escape sequences, got %q", got)
+	}
+
+	SetEnabled(true)
+	if got := Foreground(Red, "red"); got != "\033[31mred\033[0m" {
+		t.Errorf("Foreground() with color enabled = %q", got)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{"Not a terminal", map[string]string{"TERM": "xterm"}, false},
+		{"FORCE_COLOR", map[string]string{"FORCE_COLOR": "1"}, true},
+		{"FORCE_COLOR=0", map[string]string{"FORCE_COLOR": "0"}, false},
+		{"NO_COLOR wins", map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, false},
+		{"TERM=dumb", map[string]string{"TERM": "dumb"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "TERM"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			if got := Supported(&bytes.Buffer{}); got != tc.expected {
+				t.Errorf("Supported() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
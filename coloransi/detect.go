@@ -0,0 +1,82 @@
+package coloransi
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// disabled turns every formatting function into plain text, see SetEnabled
+var disabled atomic.Bool
+
+func init() {
+	disabled.Store(!envAllowsColor())
+}
+
+// SetEnabled turns ANSI output of the formatting functions on or off process wide.
+// It starts disabled when NO_COLOR is set, FORCE_COLOR is 0 or false, or TERM is dumb.
+func SetEnabled(enabled bool) {
+	disabled.Store(!enabled)
+}
+
+// Enabled reports whether the formatting functions emit ANSI escape sequences.
+func Enabled() bool {
+	return !disabled.Load()
+}
+
+// Supported reports whether w should receive ANSI escape sequences: NO_COLOR
+// disables and FORCE_COLOR enables color regardless of w, TERM=dumb disables
+// it, and otherwise w must be a terminal.
+func Supported(w io.Writer) bool {
+	if force, ok := forceColor(); ok {
+		return force
+	}
+	if !envAllowsColor() {
+		return false
+	}
+	return IsTerminal(w)
+}
+
+// IsTerminal reports whether w is a file attached to a character device such as a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(interface{ Stat() (os.FileInfo, error) })
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// envAllowsColor reports whether the environment permits color at all
+func envAllowsColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force, ok := forceColor(); ok {
+		return force
+	}
+	return os.Getenv("TERM") != "dumb"
+}
+
+// forceColor returns the FORCE_COLOR setting; ok is false when it is unset
+func forceColor() (force bool, ok bool) {
+	v, ok := os.LookupEnv("FORCE_COLOR")
+	if !ok {
+		return false, false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false, true
+	}
+	return v != "0" && v != "false", true
+}
+
+// joinArgs renders the arguments like the formatting functions, without escapes
+func joinArgs(v []interface{}) string {
+	args := make([]string, len(v))
+	for i, arg := range v {
+		args[i] = fmt.Sprint(arg)
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,219 @@
+// Package logadmin provides an http.Handler for viewing and changing the levels
+// of registered loggers at runtime.
+package logadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+	"github.com/Moonlight-Companies/gologger/logger"
+)
+
+// LoggerInfo describes a registered logger in GET responses
+type LoggerInfo struct {
+	Name     string     `json:"name"`
+	Prefix   string     `json:"prefix"`
+	Level    string     `json:"level"`
+	Lines    uint64     `json:"lines"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+// LevelChange is the body accepted by PUT and POST. The same values may be
+// passed as query or form parameters instead.
+type LevelChange struct {
+	Name  string `json:"name"`  // logger name or glob pattern, e.g. "plc.*"
+	Level string `json:"level"` // level name such as "debug"
+	TTL   string `json:"ttl"`   // optional duration after which the change reverts, e.g. "10m"
+}
+
+// changeResult is the response to PUT and POST
+type changeResult struct {
+	Updated  int        `json:"updated"`
+	Level    string     `json:"level"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+// pendingRevert remembers the levels to restore when a temporary change expires
+type pendingRevert struct {
+	timer    *time.Timer
+	at       time.Time
+	original map[*logger.Logger]logger.LogLevel
+	override *logger.LogLevel // registry override for the pattern before the change, if any
+}
+
+// Handler serves the registered loggers. GET lists them as JSON, PUT and POST
+// change the level of the loggers matching a name or glob pattern.
+type Handler struct {
+	mu      sync.Mutex
+	pending map[string]*pendingRevert
+}
+
+// NewHandler returns a Handler ready to be mounted on a mux,
+// e.g. mux.Handle("/debug/loggers", logadmin.NewHandler())
+func NewHandler() *Handler {
+	return &Handler{pending: make(map[string]*pendingRevert)}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.list(w)
+	case http.MethodPut, http.MethodPost:
+		h.change(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) list(w http.ResponseWriter) {
+	h.mu.Lock()
+	revertAt := make(map[*logger.Logger]time.Time)
+	for _, p := range h.pending {
+		for l := range p.original {
+			revertAt[l] = p.at
+		}
+	}
+	h.mu.Unlock()
+
+	loggers := logger.RegisteredLoggers()
+	infos := make([]LoggerInfo, 0, len(loggers))
+	for _, l := range loggers {
+		info := LoggerInfo{
+			Name:   l.Name(),
+			Prefix: coloransi.Strip(l.GetPrefix()),
+			Level:  l.GetLevel().String(),
+			Lines:  l.LineCount(),
+		}
+		if at, ok := revertAt[l]; ok {
+			info.RevertAt = &at
+		}
+		infos = append(infos, info)
+	}
+
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (h *Handler) change(w http.ResponseWriter, r *http.Request) {
+	req, err := readChange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Name == "" {
+		http.Error(w, "missing logger name", http.StatusBadRequest)
+		return
+	}
+	if _, err := path.Match(req.Name, ""); err != nil {
+		http.Error(w, fmt.Sprintf("invalid name pattern %q", req.Name), http.StatusBadRequest)
+		return
+	}
+
+	level, err := logger.ParseLevel(req.Level)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl < 0 {
+			http.Error(w, fmt.Sprintf("invalid ttl %q", req.TTL), http.StatusBadRequest)
+			return
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Capture the levels in effect before this change, keeping the originals
+	// of an earlier temporary change to the same pattern
+	original := make(map[*logger.Logger]logger.LogLevel)
+	var override *logger.LogLevel
+	if p, ok := h.pending[req.Name]; ok {
+		p.timer.Stop()
+		original, override = p.original, p.override
+		delete(h.pending, req.Name)
+	} else if prev, ok := logger.LevelFor(req.Name); ok {
+		override = &prev
+	}
+	for _, l := range logger.LoggersMatching(req.Name) {
+		if _, ok := original[l]; !ok {
+			original[l] = l.GetLevel()
+		}
+	}
+
+	updated, _ := logger.SetLevelFor(req.Name, level)
+
+	result := changeResult{Updated: updated, Level: level.String()}
+	if ttl > 0 {
+		p := &pendingRevert{at: time.Now().Add(ttl), original: original, override: override}
+		name := req.Name
+		p.timer = time.AfterFunc(ttl, func() { h.revert(name, p) })
+		h.pending[name] = p
+		result.RevertAt = &p.at
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// revert restores the levels saved for a temporary change
+func (h *Handler) revert(name string, p *pendingRevert) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.pending[name] != p {
+		// Superseded by a later change
+		return
+	}
+	delete(h.pending, name)
+
+	if p.override != nil {
+		logger.SetLevelFor(name, *p.override)
+	} else {
+		logger.ClearLevelFor(name)
+	}
+	for l, level := range p.original {
+		l.SetLevel(level)
+	}
+	// Loggers created during the change picked up its override
+	for _, l := range logger.LoggersMatching(name) {
+		if _, ok := p.original[l]; !ok {
+			l.SetLevel(l.DefaultLevel())
+		}
+	}
+}
+
+// readChange decodes a LevelChange from a JSON body or from query/form values
+func readChange(r *http.Request) (LevelChange, error) {
+	var req LevelChange
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return req, fmt.Errorf("invalid JSON body: %w", err)
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return req, err
+	}
+	req.Name = r.Form.Get("name")
+	req.Level = r.Form.Get("level")
+	req.TTL = r.Form.Get("ttl")
+	return req, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,194 @@
+package logadmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+	"github.com/Moonlight-Companies/gologger/logger"
+)
+
+func newTestLogger(t *testing.T, name string, level logger.LogLevel) *logger.Logger {
+	t.Helper()
+	var buf bytes.Buffer
+	l := logger.NewLogger(coloransi.Foreground(coloransi.Red, name), logger.WithLevel(level), logger.WithWriter(&buf), logger.WithName(name))
+	t.Cleanup(func() {
+		l.Unregister()
+		logger.ClearLevelFor(name)
+	})
+	return l
+}
+
+func list(t *testing.T, h http.Handler) map[string]LoggerInfo {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/loggers", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET returned %d", rec.Code)
+	}
+
+	var infos []LoggerInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &infos); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	result := make(map[string]LoggerInfo)
+	for _, info := range infos {
+		result[info.Name] = info
+	}
+	return result
+}
+
+func TestHandlerList(t *testing.T) {
+	l := newTestLogger(t, "admin.list", logger.LogLevelInfo)
+	l.Infoln("one")
+	l.Debugln("filtered")
+	l.Named("child").Warnln("two")
+
+	info, ok := list(t, NewHandler())["admin.list"]
+	if !ok {
+		t.Fatal("Expected admin.list in listing")
+	}
+	if info.Prefix != "admin.list" || info.Level != "INFO" || info.Lines != 2 || info.RevertAt != nil {
+		t.Errorf("Unexpected info %+v", info)
+	}
+}
+
+func TestHandlerChange(t *testing.T) {
+	a := newTestLogger(t, "admin.change.a", logger.LogLevelInfo)
+	b := newTestLogger(t, "admin.change.b", logger.LogLevelInfo)
+	h := NewHandler()
+
+	body := strings.NewReader(`{"name":"admin.change.*","level":"debug"}`)
+	req := httptest.NewRequest(http.MethodPut, "/debug/loggers", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"updated": 2`) {
+		t.Fatalf("PUT returned %d %q", rec.Code, rec.Body.String())
+	}
+	if a.GetLevel() != logger.LogLevelDebug || b.GetLevel() != logger.LogLevelDebug {
+		t.Errorf("Levels not changed: %s %s", a.GetLevel(), b.GetLevel())
+	}
+
+	form := url.Values{"name": {"admin.change.b"}, "level": {"error"}}
+	req = httptest.NewRequest(http.MethodPost, "/debug/loggers", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || b.GetLevel() != logger.LogLevelError {
+		t.Errorf("POST returned %d, level %s", rec.Code, b.GetLevel())
+	}
+	logger.ClearLevelFor("admin.change.*")
+	logger.ClearLevelFor("admin.change.b")
+}
+
+func TestHandlerTTL(t *testing.T) {
+	l := newTestLogger(t, "admin.ttl", logger.LogLevelWarn)
+	h := NewHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loggers?name=admin.ttl&level=trace&ttl=50ms", nil))
+	if rec.Code != http.StatusOK || l.GetLevel() != logger.LogLevelTrace {
+		t.Fatalf("PUT returned %d, level %s", rec.Code, l.GetLevel())
+	}
+	if info := list(t, h)["admin.ttl"]; info.RevertAt == nil {
+		t.Error("Expected revert_at in listing")
+	}
+
+	// A second temporary change keeps the original level to revert to
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loggers?name=admin.ttl&level=debug&ttl=50ms", nil))
+	if l.GetLevel() != logger.LogLevelDebug {
+		t.Fatalf("Level %s, want DEBUG", l.GetLevel())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for l.GetLevel() != logger.LogLevelWarn {
+		if time.Now().After(deadline) {
+			t.Fatalf("Level did not revert, still %s", l.GetLevel())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Loggers created while a change is active revert to their configured level
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loggers?name=admin.ttl*&level=debug&ttl=50ms", nil))
+	late := newTestLogger(t, "admin.ttl.late", logger.LogLevelInfo)
+	if late.GetLevel() != logger.LogLevelDebug {
+		t.Fatalf("Logger created during the change has level %s, want DEBUG", late.GetLevel())
+	}
+	deadline = time.Now().Add(2 * time.Second)
+	for late.GetLevel() != logger.LogLevelInfo || l.GetLevel() != logger.LogLevelWarn {
+		if time.Now().After(deadline) {
+			t.Fatalf("Levels did not revert, still %s and %s", late.GetLevel(), l.GetLevel())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if info := list(t, h)["admin.ttl"]; info.RevertAt != nil {
+		t.Error("Expected revert_at to be cleared")
+	}
+}
+
+func TestHandlerTTLKeepsOverride(t *testing.T) {
+	l := newTestLogger(t, "admin.keep.a", logger.LogLevelInfo)
+	t.Cleanup(func() { logger.ClearLevelFor("admin.keep.*") })
+	h := NewHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loggers?name=admin.keep.*&level=error", nil))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loggers?name=admin.keep.*&level=trace&ttl=50ms", nil))
+	if rec.Code != http.StatusOK || l.GetLevel() != logger.LogLevelTrace {
+		t.Fatalf("PUT returned %d, level %s", rec.Code, l.GetLevel())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for l.GetLevel() != logger.LogLevelError {
+		if time.Now().After(deadline) {
+			t.Fatalf("Level did not revert, still %s", l.GetLevel())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The permanent override still applies to loggers registered after the revert
+	if level, ok := logger.LevelFor("admin.keep.*"); !ok || level != logger.LogLevelError {
+		t.Errorf("LevelFor() = %s, %v, want ERROR", level, ok)
+	}
+	late := newTestLogger(t, "admin.keep.late", logger.LogLevelInfo)
+	if late.GetLevel() != logger.LogLevelError {
+		t.Errorf("Late logger has level %s, want ERROR", late.GetLevel())
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := NewHandler()
+	testCases := []struct {
+		name   string
+		method string
+		target string
+		status int
+	}{
+		{"Missing name", http.MethodPut, "/?level=debug", http.StatusBadRequest},
+		{"Bad level", http.MethodPut, "/?name=x&level=loud", http.StatusBadRequest},
+		{"Bad ttl", http.MethodPut, "/?name=x&level=debug&ttl=soon", http.StatusBadRequest},
+		{"Bad pattern", http.MethodPut, "/?name=%5B&level=debug", http.StatusBadRequest},
+		{"Bad method", http.MethodDelete, "/", http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+			if rec.Code != tc.status {
+				t.Errorf("Status %d, want %d", rec.Code, tc.status)
+			}
+		})
+	}
+}
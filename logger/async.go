@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// AsyncPolicy decides what happens when the async queue is full
+type AsyncPolicy int
+
+const (
+	// AsyncBlock waits for room in the queue
+	AsyncBlock AsyncPolicy = iota
+	// AsyncDropNewest discards the line being logged
+	AsyncDropNewest
+	// AsyncDropOldest discards the oldest queued line to make room
+	AsyncDropOldest
+	// AsyncDropBelowLevel discards lines below the drop level (see WithAsyncDropLevel) and blocks for the rest
+	AsyncDropBelowLevel
+)
+
+// asyncConfig holds the async options until the logger is constructed
+type asyncConfig struct {
+	enabled   bool // set by WithAsync, WithAsyncDropLevel alone leaves writes synchronous
+	queueSize int
+	policy    AsyncPolicy
+	dropLevel LogLevel
+}
+
+// asyncItem is a queued line, or a flush marker when flush is set
+type asyncItem struct {
+	sink  *sink
+	level LogLevel
+	line  string
+	flush chan struct{}
+}
+
+// asyncWriter writes queued lines from a background goroutine
+type asyncWriter struct {
+	queue     chan asyncItem
+	policy    AsyncPolicy
+	dropLevel LogLevel
+	write     func(s *sink, line string)
+	dropped   uint64 // accessed atomically
+	mu        sync.RWMutex
+	closed    bool
+	done      chan struct{}
+}
+
+// WithAsync moves writing to a background goroutine fed by a queue of the given
+// size. policy decides what happens when the queue is full. Call Close to drain
+// the queue on shutdown.
+func WithAsync(queueSize int, policy AsyncPolicy) LoggerOption {
+	return func(l *Logger) {
+		if l.asyncCfg == nil {
+			l.asyncCfg = &asyncConfig{dropLevel: LogLevelWarn}
+		}
+		l.asyncCfg.enabled = true
+		l.asyncCfg.queueSize = queueSize
+		l.asyncCfg.policy = policy
+	}
+}
+
+// WithAsyncDropLevel sets the level below which AsyncDropBelowLevel discards lines, WARN by default.
+// It has no effect unless WithAsync is also given.
+func WithAsyncDropLevel(level LogLevel) LoggerOption {
+	return func(l *Logger) {
+		if l.asyncCfg == nil {
+			l.asyncCfg = &asyncConfig{}
+		}
+		l.asyncCfg.dropLevel = level
+	}
+}
+
+func newAsyncWriter(cfg *asyncConfig, write func(s *sink, line string)) *asyncWriter {
+	queueSize := cfg.queueSize
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	a := &asyncWriter{
+		queue:     make(chan asyncItem, queueSize),
+		policy:    cfg.policy,
+		dropLevel: cfg.dropLevel,
+		write:     write,
+		done:      make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *asyncWriter) run() {
+	defer close(a.done)
+
+	for item := range a.queue {
+		if item.flush != nil {
+			close(item.flush)
+			continue
+		}
+		a.write(item.sink, item.line)
+	}
+}
+
+// enqueue queues a line according to the policy, writing directly once closed
+func (a *asyncWriter) enqueue(s *sink, level LogLevel, line string) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		a.write(s, line)
+		return
+	}
+
+	item := asyncItem{sink: s, level: level, line: line}
+	switch a.policy {
+	case AsyncDropNewest:
+		select {
+		case a.queue <- item:
+		default:
+			atomic.AddUint64(&a.dropped, 1)
+		}
+	case AsyncDropOldest:
+		for {
+			select {
+			case a.queue <- item:
+				return
+			default:
+			}
+			select {
+			case old := <-a.queue:
+				if old.flush != nil {
+					// Never lose a flush marker, release its waiter instead
+					close(old.flush)
+				} else {
+					atomic.AddUint64(&a.dropped, 1)
+				}
+			default:
+			}
+		}
+	case AsyncDropBelowLevel:
+		select {
+		case a.queue <- item:
+		default:
+			if level < a.dropLevel {
+				atomic.AddUint64(&a.dropped, 1)
+				return
+			}
+			a.queue <- item
+		}
+	default:
+		a.queue <- item
+	}
+}
+
+// flush waits until every line queued before the call has been written
+func (a *asyncWriter) flush(ctx context.Context) error {
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		return nil
+	}
+
+	marker := make(chan struct{})
+	select {
+	case a.queue <- asyncItem{flush: marker}:
+		a.mu.RUnlock()
+	case <-ctx.Done():
+		a.mu.RUnlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-marker:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close drains the queue and stops the background goroutine
+func (a *asyncWriter) close() {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+}
+
+// Dropped returns the number of lines discarded because the async queue was full
+func (l *Logger) Dropped() uint64 {
+	if l.async == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&l.async.dropped)
+}
+
+// Flush waits until all lines logged so far have been written or ctx is done
+func (l *Logger) Flush(ctx context.Context) error {
+	if l.async == nil {
+		return nil
+	}
+	return l.async.flush(ctx)
+}
+
+// Close writes any pending duplicate summary, drains queued lines and stops
+// background writing. Loggers derived via With and Named share the queue, so
+// closing any of them closes it for all. Lines logged after Close are written
+// synchronously.
+func (l *Logger) Close() error {
+	if l.dedup != nil {
+		l.dedup.flush()
+	}
+	if l.async != nil {
+		l.async.close()
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter blocks every write until the gate is opened
+type gatedWriter struct {
+	gate chan struct{}
+	mu   sync.Mutex
+	buf  bytes.Buffer
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{gate: make(chan struct{})}
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+// TestAsyncFlushAndClose tests that queued lines are written in order on Flush and Close
+func TestAsyncFlushAndClose(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithAsync(16, AsyncBlock))
+
+	for i := 0; i < 5; i++ {
+		logger.Info("line %d", i)
+	}
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned %v", err)
+	}
+
+	var expected strings.Builder
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(&expected, "0001/01/01 00:00:00.000000 INFO: TEST line %d\n", i)
+	}
+	if output := buf.String(); output != expected.String() {
+		t.Errorf("Expected output %q, got: %q", expected.String(), output)
+	}
+
+	logger.Infoln("last")
+	logger.Close()
+	logger.Infoln("after close")
+	expected.WriteString("0001/01/01 00:00:00.000000 INFO: TEST last\n")
+	expected.WriteString("0001/01/01 00:00:00.000000 INFO: TEST after close\n")
+	if output := buf.String(); output != expected.String() {
+		t.Errorf("Expected output %q, got: %q", expected.String(), output)
+	}
+}
+
+// TestAsyncDropPolicies tests the counters and surviving lines for each drop policy
+func TestAsyncDropPolicies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   AsyncPolicy
+		dropped  uint64
+		contains []string
+		missing  []string
+	}{
+		{
+			name:     "Drop newest",
+			policy:   AsyncDropNewest,
+			dropped:  2,
+			contains: []string{"debug 1", "info 2"},
+			missing:  []string{"warn 3", "error 4"},
+		},
+		{
+			name:     "Drop oldest",
+			policy:   AsyncDropOldest,
+			dropped:  2,
+			contains: []string{"warn 3", "error 4"},
+			missing:  []string{"debug 1", "info 2"},
+		},
+		{
+			name:     "Drop below level",
+			policy:   AsyncDropBelowLevel,
+			dropped:  0,
+			contains: []string{"debug 1", "info 2", "warn 3", "error 4"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newGatedWriter()
+			logger := NewLogger("TEST", WithZeroTime(), WithWriter(w), WithAsync(2, tc.policy))
+
+			// The first line is picked up by the writer goroutine and blocks there
+			logger.Infoln("blocked 0")
+			waitForQueue(t, logger, 0)
+
+			logger.Debugln("debug 1")
+			logger.Infoln("info 2")
+			if tc.policy == AsyncDropBelowLevel {
+				// Queue is full: lines below WARN are dropped, the rest must wait
+				logger.Debugln("dropped debug")
+				if dropped := logger.Dropped(); dropped != 1 {
+					t.Errorf("Dropped() = %d, want 1", dropped)
+				}
+				close(w.gate)
+				logger.Warnln("warn 3")
+				logger.Errorln("error 4")
+				tc.dropped = 1
+			} else {
+				logger.Warnln("warn 3")
+				logger.Errorln("error 4")
+				close(w.gate)
+			}
+			logger.Close()
+
+			if dropped := logger.Dropped(); dropped != tc.dropped {
+				t.Errorf("Dropped() = %d, want %d", dropped, tc.dropped)
+			}
+			output := w.String()
+			for _, s := range tc.contains {
+				if !strings.Contains(output, s) {
+					t.Errorf("Expected %q in output %q", s, output)
+				}
+			}
+			for _, s := range tc.missing {
+				if strings.Contains(output, s) {
+					t.Errorf("Did not expect %q in output %q", s, output)
+				}
+			}
+		})
+	}
+}
+
+// TestAsyncFlushContext tests that Flush gives up when its context expires
+func TestAsyncFlushContext(t *testing.T) {
+	w := newGatedWriter()
+	logger := NewLogger("TEST", WithWriter(w), WithAsync(4, AsyncBlock))
+	logger.Infoln("blocked")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := logger.Flush(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Flush returned %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(w.gate)
+	logger.Close()
+}
+
+// waitForQueue waits until the async queue holds n items
+func waitForQueue(t *testing.T, l *Logger, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for len(l.async.queue) != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Queue length %d, want %d", len(l.async.queue), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestAsyncDropLevelAlone tests that WithAsyncDropLevel does not enable async writing
+func TestAsyncDropLevelAlone(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithAsyncDropLevel(LogLevelError))
+
+	logger.Infoln("sync")
+	if logger.async != nil {
+		t.Error("Expected WithAsyncDropLevel alone to keep writes synchronous")
+	}
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 INFO: TEST sync\n" {
+		t.Errorf("Expected the line to be written immediately, got: %q", output)
+	}
+}
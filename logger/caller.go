@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Caller identifies the source location that emitted a log line
+type Caller struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// callerDepth is the number of frames between output and the user's call site:
+// output <- log/logln <- Debug/Info/... <- caller
+const callerDepth = 3
+
+// WithCaller adds the short file:line of the call site to every line. skip
+// drops additional frames, for loggers only ever called through a helper.
+func WithCaller(skip int) LoggerOption {
+	return func(l *Logger) {
+		l.caller = true
+		l.callerSkip += skip
+	}
+}
+
+// WithCallerFunction also includes the calling function name when WithCaller is enabled
+func WithCallerFunction(include bool) LoggerOption {
+	return func(l *Logger) {
+		l.callerFunc = include
+	}
+}
+
+// CallerSkip returns a derived logger that reports call sites n frames further
+// up the stack. Use it in wrapper helpers so lines point at the wrapper's caller.
+// The derived logger always follows the level of l.
+func (l *Logger) CallerSkip(n int) *Logger {
+	child := l.derive()
+	child.callerSkip += n
+	if child.levelParent == nil {
+		child.levelParent = l
+	}
+	return child
+}
+
+// String returns the short "file.go:123" form of the caller
+func (c Caller) String() string {
+	if c.File == "" {
+		return ""
+	}
+	return filepath.Base(c.File) + ":" + strconv.Itoa(c.Line)
+}
+
+// ShortFunction returns the function name without its package path
+func (c Caller) ShortFunction() string {
+	if i := strings.LastIndexByte(c.Function, '/'); i >= 0 {
+		return c.Function[i+1:]
+	}
+	return c.Function
+}
+
+// captureCaller resolves the call site skip frames above its caller
+func captureCaller(skip int) Caller {
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return Caller{}
+	}
+
+	c := Caller{File: file, Line: line}
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		c.Function = fn.Name()
+	}
+	return c
+}
+
+// callerFromPC resolves a program counter from runtime.Callers
+func callerFromPC(pc uintptr) Caller {
+	if pc == 0 {
+		return Caller{}
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+}
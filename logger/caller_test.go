@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+// line returns the line number of its call site
+func line() int {
+	_, _, n, _ := runtime.Caller(1)
+	return n
+}
+
+// logThroughHelper is a wrapper helper used to test CallerSkip
+func logThroughHelper(l *Logger, msg string) {
+	l.CallerSkip(1).Infoln(msg)
+}
+
+// TestLoggerCaller tests file:line reporting across the logging methods
+func TestLoggerCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithCaller(0))
+
+	testCases := []struct {
+		name string
+		log  func() int
+	}{
+		{"Debug", func() int { logger.Debug("msg"); return line() }},
+		{"Debugln", func() int { logger.Debugln("msg"); return line() }},
+		{"DebugKV", func() int { logger.DebugKV("msg"); return line() }},
+		{"With", func() int { logger.With("a", 1).Infoln("msg"); return line() }},
+		{"Writer", func() int { logger.Writer(LogLevelInfo).Write([]byte("msg\n")); return line() }},
+		{"StdLogger", func() int { logger.StdLogger(LogLevelInfo).Print("msg"); return line() }},
+		{"Helper", func() int { logThroughHelper(logger, "msg"); return line() }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf.Reset()
+			n := tc.log()
+
+			expected := fmt.Sprintf(" caller_test.go:%d msg", n)
+			if output := buf.String(); !bytes.Contains([]byte(output), []byte(expected)) {
+				t.Errorf("Expected output to contain %q, got: %q", expected, output)
+			}
+		})
+	}
+}
+
+// TestLoggerCallerFunction tests the optional function name and the disabled default
+func TestLoggerCallerFunction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithCaller(0), WithCallerFunction(true))
+
+	logger.Infoln("msg")
+	n := line() - 1
+	expected := fmt.Sprintf("0001/01/01 00:00:00.000000 INFO: TEST caller_test.go:%d logger.TestLoggerCallerFunction msg\n", n)
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	buf.Reset()
+	NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithEncoder(JSONEncoder{}), WithCaller(0)).Infoln("msg")
+	n = line() - 1
+	expected = fmt.Sprintf(`{"time":"0001-01-01T00:00:00Z","level":"INFO","prefix":"TEST","msg":"msg","caller":"caller_test.go:%d"}`+"\n", n)
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	buf.Reset()
+	NewLogger("TEST", WithZeroTime(), WithWriter(&buf)).Infoln("msg")
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 INFO: TEST msg\n" {
+		t.Errorf("Expected no caller without WithCaller, got: %q", output)
+	}
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// Special layouts for WithTimeFormat; any other value is a time.Format layout
+const (
+	TimeFormatNone        = "none"        // omit the timestamp
+	TimeFormatEpochMillis = "epochmillis" // milliseconds since the Unix epoch
+	TimeFormatElapsed     = "elapsed"     // seconds since logger creation, from the monotonic clock
+)
+
+// Clock supplies the current time for timestamps and delta times
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// WithClock replaces the system clock, so tests can control timestamps and delta times
+func WithClock(c Clock) LoggerOption {
+	return func(l *Logger) {
+		l.clock = c
+	}
+}
+
+// WithTimeFormat sets the timestamp layout for every encoder, e.g. time.RFC3339Nano,
+// TimeFormatEpochMillis, TimeFormatNone or TimeFormatElapsed. By default each
+// encoder uses its own layout.
+func WithTimeFormat(layout string) LoggerOption {
+	return func(l *Logger) {
+		l.timeFormat = layout
+	}
+}
+
+// WithLocation renders timestamps in loc, e.g. time.UTC, instead of local time
+func WithLocation(loc *time.Location) LoggerOption {
+	return func(l *Logger) {
+		l.location = loc
+	}
+}
+
+// formatEntryTime renders the timestamp of e using its TimeFormat, falling back
+// to layout. ok is false when the timestamp should be omitted.
+func formatEntryTime(e *Entry, layout string) (s string, ok bool) {
+	switch e.TimeFormat {
+	case "":
+		return e.Time.Format(layout), true
+	case TimeFormatNone:
+		return "", false
+	case TimeFormatEpochMillis:
+		return strconv.FormatInt(e.Time.UnixMilli(), 10), true
+	case TimeFormatElapsed:
+		return strconv.FormatFloat(e.DeltaTime.Seconds(), 'f', 6, 64), true
+	default:
+		return e.Time.Format(e.TimeFormat), true
+	}
+}
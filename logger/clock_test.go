@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClock is a Clock that only moves when advanced
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// TestLoggerClock tests that the injected clock drives timestamps and delta times
+func TestLoggerClock(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &testClock{now: time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)}
+	logger := NewLogger("TEST", WithWriter(&buf), WithClock(clock), WithLocation(time.UTC), WithDeltaTime(true))
+
+	logger.Infoln("start")
+	clock.Advance(1500 * time.Millisecond)
+	logger.Named("child").Infoln("later")
+
+	expected := "2024/03/04 05:06:07.000000 INFO 0s: TEST start\n" +
+		"2024/03/04 05:06:08.500000 INFO 1.5s: TEST.child later\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerTimeFormat tests the timestamp layouts across encoders
+func TestLoggerTimeFormat(t *testing.T) {
+	start := time.Date(2024, 3, 4, 5, 6, 7, 8000000, time.UTC)
+	testCases := []struct {
+		name     string
+		encoder  Encoder
+		options  []LoggerOption
+		elapse   time.Duration
+		dedup    bool // log the line three times and close, adding a repeat summary
+		expected string
+	}{
+		{
+			name:     "RFC3339Nano in a named location",
+			encoder:  TextEncoder{},
+			options:  []LoggerOption{WithTimeFormat(time.RFC3339Nano), WithLocation(time.FixedZone("PLANT", 2*3600))},
+			expected: "2024-03-04T07:06:07.008+02:00 INFO: TEST msg\n",
+		},
+		{
+			name:     "None",
+			encoder:  TextEncoder{},
+			options:  []LoggerOption{WithTimeFormat(TimeFormatNone)},
+			expected: "INFO: TEST msg\n",
+		},
+		{
+			name:     "Elapsed",
+			encoder:  TextEncoder{},
+			options:  []LoggerOption{WithTimeFormat(TimeFormatElapsed)},
+			elapse:   2250 * time.Millisecond,
+			expected: "2.250000 INFO: TEST msg\n",
+		},
+		{
+			name:     "Epoch millis JSON",
+			encoder:  JSONEncoder{},
+			options:  []LoggerOption{WithTimeFormat(TimeFormatEpochMillis)},
+			expected: `{"time":1709528767008,"level":"INFO","prefix":"TEST","msg":"msg"}` + "\n",
+		},
+		{
+			name:     "None JSON",
+			encoder:  JSONEncoder{},
+			options:  []LoggerOption{WithTimeFormat(TimeFormatNone)},
+			expected: `{"level":"INFO","prefix":"TEST","msg":"msg"}` + "\n",
+		},
+		{
+			name:    "None with dedup",
+			encoder: TextEncoder{},
+			options: []LoggerOption{WithTimeFormat(TimeFormatNone)},
+			dedup:   true,
+			expected: "INFO: TEST msg\n" +
+				"INFO: TEST last message repeated 2 times\n",
+		},
+		{
+			name:    "Elapsed with dedup",
+			encoder: TextEncoder{},
+			options: []LoggerOption{WithTimeFormat(TimeFormatElapsed)},
+			elapse:  2250 * time.Millisecond,
+			dedup:   true,
+			expected: "2.250000 INFO: TEST msg\n" +
+				"2.250000 INFO: TEST last message repeated 2 times\n",
+		},
+		{
+			name:    "Epoch millis JSON with dedup",
+			encoder: JSONEncoder{},
+			options: []LoggerOption{WithTimeFormat(TimeFormatEpochMillis)},
+			dedup:   true,
+			expected: `{"time":1709528767008,"level":"INFO","prefix":"TEST","msg":"msg"}` + "\n" +
+				`{"time":1709528767008,"level":"INFO","prefix":"TEST","msg":"last message repeated 2 times"}` + "\n",
+		},
+		{
+			name:     "None logfmt",
+			encoder:  LogfmtEncoder{},
+			options:  []LoggerOption{WithTimeFormat(TimeFormatNone)},
+			expected: "level=info prefix=TEST msg=msg\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			clock := &testClock{now: start}
+			options := append([]LoggerOption{WithWriter(&buf), WithEncoder(tc.encoder), WithClock(clock)}, tc.options...)
+			if tc.dedup {
+				options = append(options, WithDedup(time.Hour))
+			}
+			logger := NewLogger("TEST", options...)
+
+			clock.Advance(tc.elapse)
+			logger.Infoln("msg")
+			if tc.dedup {
+				logger.Infoln("msg")
+				logger.Infoln("msg")
+				logger.Close()
+			}
+
+			if output := buf.String(); output != tc.expected {
+				t.Errorf("Expected output %q, got: %q", tc.expected, output)
+			}
+			if _, ok := tc.encoder.(JSONEncoder); ok {
+				for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+					if !json.Valid(line) {
+						t.Errorf("Invalid JSON %q", line)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestLoggerZeroTimeWithClock tests that WithZeroTime still wins over the clock
+func TestLoggerZeroTimeWithClock(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithClock(&testClock{now: time.Now()}))
+
+	logger.Infoln("zero")
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 INFO: TEST zero\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+}
+
+// steppingClock is a Clock that advances by step on every reading
+type steppingClock struct {
+	testClock
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	now := c.testClock.Now()
+	c.Advance(c.step)
+	return now
+}
+
+// TestLoggerSingleClockReading tests that an entry's time and delta time come from one reading
+func TestLoggerSingleClockReading(t *testing.T) {
+	start := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	clock := &steppingClock{testClock: testClock{now: start}, step: time.Millisecond}
+	logger := NewLogger("TEST", WithWriter(&bytes.Buffer{}), WithClock(clock), WithDeltaTime(true))
+
+	var entries []Entry
+	logger.AddHook(nil, func(e *Entry) error {
+		entries = append(entries, *e)
+		return nil
+	})
+	logger.Infoln("one")
+	logger.Infoln("two")
+
+	for _, e := range entries {
+		if delta := e.Time.Sub(start); delta != e.DeltaTime {
+			t.Errorf("Time is %s after creation but delta time is %s", delta, e.DeltaTime)
+		}
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 entries, got %d", len(entries))
+	}
+}
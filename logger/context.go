@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"context"
+	"sync"
+)
+
+// contextLoggerKey is the context key NewContext stores the logger under
+type contextLoggerKey struct{}
+
+// contextKey maps a context value key to the field name it is logged as
+type contextKey struct {
+	key  interface{}
+	name string
+}
+
+var (
+	contextKeysMu sync.RWMutex
+	contextKeys   []contextKey
+)
+
+// NewContext returns a copy of ctx carrying l, retrieved with FromContext
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextLoggerKey{}, l)
+}
+
+// FromContext returns the logger attached to ctx by NewContext, or the global Log
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextLoggerKey{}).(*Logger); ok && l != nil {
+			return l
+		}
+	}
+	return Log
+}
+
+// RegisterContextKey makes the Ctx methods log the value stored in a context
+// under key as the field name, e.g. RegisterContextKey(requestIDKey{}, "request_id").
+// Registering a key again changes its field name.
+func RegisterContextKey(key interface{}, name string) {
+	contextKeysMu.Lock()
+	defer contextKeysMu.Unlock()
+
+	for i := range contextKeys {
+		if contextKeys[i].key == key {
+			contextKeys[i].name = name
+			return
+		}
+	}
+	contextKeys = append(contextKeys, contextKey{key: key, name: name})
+}
+
+// UnregisterContextKey stops logging the context value stored under key
+func UnregisterContextKey(key interface{}) {
+	contextKeysMu.Lock()
+	defer contextKeysMu.Unlock()
+
+	for i := range contextKeys {
+		if contextKeys[i].key == key {
+			contextKeys = append(contextKeys[:i], contextKeys[i+1:]...)
+			return
+		}
+	}
+}
+
+// ContextFields returns a field for every registered key with a value in ctx,
+// in registration order
+func ContextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	contextKeysMu.RLock()
+	defer contextKeysMu.RUnlock()
+
+	var fields []Field
+	for _, k := range contextKeys {
+		if v := ctx.Value(k.key); v != nil {
+			fields = append(fields, Any(k.name, v))
+		}
+	}
+	return fields
+}
+
+// TraceCtx logs a formatted message at TRACE level with the registered fields of ctx
+func (l *Logger) TraceCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelTrace {
+		l.log(LogLevelTrace, ContextFields(ctx), format, v...)
+	}
+}
+
+// DebugCtx logs a formatted message at DEBUG level with the registered fields of ctx
+func (l *Logger) DebugCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelDebug {
+		l.log(LogLevelDebug, ContextFields(ctx), format, v...)
+	}
+}
+
+// InfoCtx logs a formatted message at INFO level with the registered fields of ctx
+func (l *Logger) InfoCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelInfo {
+		l.log(LogLevelInfo, ContextFields(ctx), format, v...)
+	}
+}
+
+// WarnCtx logs a formatted message at WARN level with the registered fields of ctx
+func (l *Logger) WarnCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelWarn {
+		l.log(LogLevelWarn, ContextFields(ctx), format, v...)
+	}
+}
+
+// ErrorCtx logs a formatted message at ERROR level with the registered fields of ctx
+func (l *Logger) ErrorCtx(ctx context.Context, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelError {
+		l.log(LogLevelError, ContextFields(ctx), format, v...)
+	}
+}
+
+// LogCtx logs a formatted message at the given level with the registered fields of ctx
+func (l *Logger) LogCtx(ctx context.Context, level LogLevel, format string, v ...interface{}) {
+	if l.GetLevel() <= level {
+		l.log(level, ContextFields(ctx), format, v...)
+	}
+}
+
+// LogKVCtx logs a message with the registered fields of ctx followed by the given fields
+func (l *Logger) LogKVCtx(ctx context.Context, level LogLevel, msg string, kv ...interface{}) {
+	if l.GetLevel() <= level {
+		l.logln(level, append(ContextFields(ctx), fieldsFromKV(kv)...), msg)
+	}
+}
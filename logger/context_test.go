@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testRequestIDKey struct{}
+type testTenantKey struct{}
+
+// TestLoggerContext tests storing and retrieving a logger from a context
+func TestLoggerContext(t *testing.T) {
+	l := NewLogger("TEST")
+	if got := FromContext(NewContext(context.Background(), l)); got != l {
+		t.Errorf("FromContext() = %p, want %p", got, l)
+	}
+	if got := FromContext(context.Background()); got != Log {
+		t.Error("Expected FromContext without a logger to return the global Log")
+	}
+	var nilCtx context.Context
+	if got := FromContext(nilCtx); got != Log {
+		t.Error("Expected FromContext(nil) to return the global Log")
+	}
+}
+
+// TestLoggerCtxFields tests that registered context values become fields
+func TestLoggerCtxFields(t *testing.T) {
+	RegisterContextKey(testRequestIDKey{}, "request_id")
+	RegisterContextKey(testTenantKey{}, "tenant")
+	t.Cleanup(func() {
+		UnregisterContextKey(testRequestIDKey{})
+		UnregisterContextKey(testTenantKey{})
+	})
+
+	var buf bytes.Buffer
+	l := NewLogger("TEST", WithLevel(LogLevelInfo), WithZeroTime(), WithWriter(&buf)).With("svc", "plc")
+
+	ctx := context.WithValue(context.Background(), testRequestIDKey{}, "r-42")
+	ctx = NewContext(ctx, l)
+
+	FromContext(ctx).InfoCtx(ctx, "handled %d", 1)
+	FromContext(ctx).DebugCtx(ctx, "hidden")
+	FromContext(ctx).LogKVCtx(context.WithValue(ctx, testTenantKey{}, "acme"), LogLevelWarn, "slow", "ms", 900)
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST handled 1 svc=plc request_id=r-42\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST slow svc=plc request_id=r-42 tenant=acme ms=900\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	RegisterContextKey(testRequestIDKey{}, "rid")
+	UnregisterContextKey(testTenantKey{})
+	fields := ContextFields(context.WithValue(ctx, testTenantKey{}, "acme"))
+	if len(fields) != 1 || fields[0].Key != "rid" {
+		t.Errorf("ContextFields() = %v, want only rid", fields)
+	}
+}
+
+// TestLoggerCtxCaller tests that the Ctx methods report the user's call site
+func TestLoggerCtxCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithCaller(0))
+
+	l.ErrorCtx(context.Background(), "failed")
+	expected := fmt.Sprintf("context_test.go:%d failed", line()-1)
+	if output := buf.String(); !strings.Contains(output, expected) {
+		t.Errorf("Expected %q in output, got: %q", expected, output)
+	}
+}
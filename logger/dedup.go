@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dedupKey identifies a rendered line for duplicate detection
+type dedupKey struct {
+	level   LogLevel
+	prefix  string
+	message string
+	fields  string
+}
+
+// dedup collapses consecutive identical lines, like syslogd's
+// "last message repeated N times"
+type dedup struct {
+	timeout time.Duration
+	stamp   func(e *Entry)
+	write   func(e *Entry)
+
+	mu      sync.Mutex
+	last    dedupKey
+	has     bool
+	repeats int
+	timer   *time.Timer
+	gen     uint64 // invalidates timers armed for earlier repeats
+}
+
+// WithDedup collapses consecutive lines with the same level, prefix, message and fields.
+// The first is written; the repeats are reported as a single "last message
+// repeated N times" line once a different line arrives, timeout passes, or the
+// logger is closed.
+func WithDedup(timeout time.Duration) LoggerOption {
+	return func(l *Logger) {
+		l.dedup = &dedup{
+			timeout: timeout,
+			stamp:   l.stampEntry,
+			write:   l.writeEntry,
+		}
+	}
+}
+
+// observe records an entry, reporting whether it repeats the previous line and
+// must be suppressed. A pending summary is written before a different line.
+func (d *dedup) observe(e *Entry) bool {
+	var fields strings.Builder
+	appendFields(&fields, e.Fields)
+	key := dedupKey{level: e.Level, prefix: e.Prefix, message: e.Message, fields: fields.String()}
+
+	d.mu.Lock()
+	if d.has && key == d.last {
+		d.repeats++
+		if d.timer == nil && d.timeout > 0 {
+			gen := d.gen
+			d.timer = time.AfterFunc(d.timeout, func() { d.expire(gen) })
+		}
+		d.mu.Unlock()
+		return true
+	}
+
+	summary := d.takeSummary()
+	d.last = key
+	d.has = true
+	d.mu.Unlock()
+
+	if summary != nil {
+		d.write(summary)
+	}
+	return false
+}
+
+// expire writes the summary when the timeout passes without a different line
+func (d *dedup) expire(gen uint64) {
+	d.mu.Lock()
+	if gen != d.gen {
+		d.mu.Unlock()
+		return
+	}
+	summary := d.takeSummary()
+	d.mu.Unlock()
+
+	if summary != nil {
+		d.write(summary)
+	}
+}
+
+// flush writes any pending summary immediately
+func (d *dedup) flush() {
+	d.mu.Lock()
+	summary := d.takeSummary()
+	d.mu.Unlock()
+
+	if summary != nil {
+		d.write(summary)
+	}
+}
+
+// takeSummary resets the repeat count and returns the summary entry for it,
+// nil when there were no repeats. The caller must hold d.mu.
+func (d *dedup) takeSummary() *Entry {
+	d.gen++
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if d.repeats == 0 {
+		return nil
+	}
+
+	n := d.repeats
+	d.repeats = 0
+	e := &Entry{
+		Level:   d.last.level,
+		Prefix:  d.last.prefix,
+		Message: fmt.Sprintf("last message repeated %d times", n),
+	}
+	d.stamp(e)
+	return e
+}
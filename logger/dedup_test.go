@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLoggerDedup tests that repeats are collapsed until a different line arrives
+func TestLoggerDedup(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithDedup(time.Minute))
+
+	for i := 0; i < 4; i++ {
+		logger.Warnln("link down")
+	}
+	logger.Infoln("link down")
+	logger.InfoKV("status", "port", 1)
+	logger.InfoKV("status", "port", 2)
+	logger.Named("child").Infoln("other")
+
+	expected := "0001/01/01 00:00:00.000000 WARN: TEST link down\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST last message repeated 3 times\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST link down\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST status port=1\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST status port=2\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST.child other\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+	if count := logger.LineCount(); count != 5 {
+		t.Errorf("LineCount() = %d, want 5", count)
+	}
+}
+
+// TestLoggerDedupTimeout tests that the summary is written once the timeout passes
+func TestLoggerDedupTimeout(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithDedup(20*time.Millisecond))
+
+	logger.Error("retry %d", 1)
+	logger.Error("retry %d", 1)
+	logger.Error("retry %d", 1)
+
+	summary := "0001/01/01 00:00:00.000000 ERROR: TEST last message repeated 2 times\n"
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.HasSuffix(buf.String(), summary) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Summary line not written, got: %q", buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Later repeats are still collapsed and reported on Close
+	logger.Error("retry %d", 1)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	expected := "0001/01/01 00:00:00.000000 ERROR: TEST retry 1\n" + summary +
+		"0001/01/01 00:00:00.000000 ERROR: TEST last message repeated 1 times\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// Entry is a single log event as handed to an Encoder
+type Entry struct {
+	Time         time.Time
+	Level        LogLevel
+	Prefix       string
+	Message      string
+	DeltaTime    time.Duration // time since logger creation, valid when HasDeltaTime is set or TimeFormat is TimeFormatElapsed
+	HasDeltaTime bool
+	Fields       []Field
+	Caller       Caller  // zero unless caller reporting is enabled
+	Errors       []error // errors passed as arguments or fields
+	Stack        string  // stack trace of the calling goroutine, if enabled
+	Tag          Field   // correlation field rendered as a colored tag, zero Key when unset
+	Color        bool    // set while encoding for a sink that accepts ANSI escape sequences
+	TimeFormat   string  // layout set via WithTimeFormat, empty for the encoder's default
+}
+
+// Encoder renders an Entry into a complete log line including the trailing newline
+type Encoder interface {
+	Encode(e *Entry) string
+}
+
+// TextEncoder renders the human readable layout:
+// "2006/01/02 15:04:05.000000 LEVEL: prefix [file.go:123 [func]] [tag] message key=value"
+// followed by wrapped error causes and the stack trace on indented lines.
+// Colors are only added for sinks that accept ANSI escape sequences.
+type TextEncoder struct {
+	LevelBadges   bool                    // render the level on a background of its color
+	ColorMessages bool                    // color the message of WARN and more severe lines
+	LevelStyles   map[LogLevel]LevelStyle // overrides the registered level colors
+}
+
+// LevelStyle is the color and text style of a level badge
+type LevelStyle struct {
+	Color coloransi.ColorCode
+	Style coloransi.TextStyle
+}
+
+// Encode implements Encoder
+func (enc TextEncoder) Encode(e *Entry) string {
+	var sb strings.Builder
+	if ts, ok := formatEntryTime(e, "2006/01/02 15:04:05.000000"); ok {
+		sb.WriteString(ts)
+		sb.WriteByte(' ')
+	}
+	if enc.LevelBadges && e.Color {
+		style := enc.levelStyle(e.Level)
+		sb.WriteString(coloransi.ColorAndStyle(style.Color.GetContrast(), style.Color, style.Style, e.Level.String()))
+	} else {
+		sb.WriteString(e.Level.String())
+	}
+	if e.HasDeltaTime {
+		sb.WriteByte(' ')
+		sb.WriteString(e.DeltaTime.String())
+	}
+	sb.WriteString(": ")
+	sb.WriteString(e.Prefix)
+	sb.WriteByte(' ')
+	if e.Caller.File != "" {
+		sb.WriteString(e.Caller.String())
+		sb.WriteByte(' ')
+		if e.Caller.Function != "" {
+			sb.WriteString(e.Caller.ShortFunction())
+			sb.WriteByte(' ')
+		}
+	}
+	if e.Color {
+		appendTag(&sb, e.Tag)
+	}
+	if enc.ColorMessages && e.Color && e.Level >= LogLevelWarn {
+		sb.WriteString(coloransi.Foreground(enc.levelStyle(e.Level).Color, e.Message))
+	} else {
+		sb.WriteString(e.Message)
+	}
+	appendFields(&sb, e.Fields)
+	sb.WriteByte('\n')
+	appendErrorDetails(&sb, e)
+	return sb.String()
+}
+
+// levelStyle returns the configured style of level, defaulting to its registered color
+func (enc TextEncoder) levelStyle(level LogLevel) LevelStyle {
+	if style, ok := enc.LevelStyles[level]; ok {
+		return style
+	}
+	return LevelStyle{Color: LevelColor(level)}
+}
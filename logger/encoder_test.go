@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// TestTextEncoderLevelBadges tests colored level badges and messages
+func TestTextEncoderLevelBadges(t *testing.T) {
+	var buf bytes.Buffer
+	enc := TextEncoder{
+		LevelBadges:   true,
+		ColorMessages: true,
+		LevelStyles:   map[LogLevel]LevelStyle{LogLevelError: {Color: coloransi.BrightRed, Style: coloransi.Bold}},
+	}
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithEncoder(enc), WithColor(ColorAlways))
+
+	logger.Infoln("ready")
+	logger.Warnln("slow")
+	logger.Errorln("failed")
+
+	info := coloransi.ColorAndStyle(coloransi.Green.GetContrast(), coloransi.Green, 0, "INFO")
+	warn := coloransi.ColorAndStyle(coloransi.Yellow.GetContrast(), coloransi.Yellow, 0, "WARN")
+	errBadge := coloransi.ColorAndStyle(coloransi.BrightRed.GetContrast(), coloransi.BrightRed, coloransi.Bold, "ERROR")
+	expected := "0001/01/01 00:00:00.000000 " + info + ": TEST ready\n" +
+		"0001/01/01 00:00:00.000000 " + warn + ": TEST " + coloransi.Foreground(coloransi.Yellow, "slow") + "\n" +
+		"0001/01/01 00:00:00.000000 " + errBadge + ": TEST " + coloransi.Foreground(coloransi.BrightRed, "failed") + "\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestTextEncoderLevelBadgesNoColor tests that badges follow the color policy
+func TestTextEncoderLevelBadgesNoColor(t *testing.T) {
+	var buf bytes.Buffer
+	enc := TextEncoder{LevelBadges: true, ColorMessages: true}
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithEncoder(enc), WithColor(ColorNever))
+
+	logger.Errorln("failed")
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 ERROR: TEST failed\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+}
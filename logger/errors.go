@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// errorCause is one link in an error chain, depth 0 being the logged error itself
+type errorCause struct {
+	depth int
+	msg   string
+}
+
+// WithStackTrace attaches a stack trace of the calling goroutine to every line
+// at or above level
+func WithStackTrace(level LogLevel) LoggerOption {
+	return func(l *Logger) {
+		l.stackTrace = true
+		l.stackLevel = level
+	}
+}
+
+// errorChain walks err through errors.Unwrap and errors.Join, depth first
+func errorChain(err error) []errorCause {
+	var chain []errorCause
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		if err == nil || typedNil(err) {
+			return
+		}
+		chain = append(chain, errorCause{depth: depth, msg: FormatArgIntoString(err)})
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap(), depth+1)
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner, depth+1)
+			}
+		}
+	}
+	walk(err, 0)
+	return chain
+}
+
+// ErrorChain returns the messages of err and every error it wraps, depth first
+func ErrorChain(err error) []string {
+	chain := errorChain(err)
+	msgs := make([]string, len(chain))
+	for i, c := range chain {
+		msgs[i] = c.msg
+	}
+	return msgs
+}
+
+// collectErrors returns the non-nil errors among args
+func collectErrors(args []interface{}) []error {
+	var errs []error
+	for _, arg := range args {
+		if err, ok := arg.(error); ok && !typedNil(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// fieldErrors returns the non-nil errors held by fields
+func fieldErrors(fields []Field) []error {
+	var errs []error
+	for _, f := range fields {
+		if err, ok := f.Value.(error); ok && !typedNil(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// captureStack formats the stack skip frames above its caller, one
+// "function\n\tfile:line" pair per frame
+func captureStack(skip int) string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// appendErrorDetails renders wrapped causes and the stack trace on indented lines
+func appendErrorDetails(sb *strings.Builder, e *Entry) {
+	for _, err := range e.Errors {
+		chain := errorChain(err)
+		if len(chain) < 2 {
+			continue
+		}
+		for _, c := range chain[1:] {
+			sb.WriteString(strings.Repeat("    ", c.depth))
+			sb.WriteString("caused by: ")
+			sb.WriteString(strings.ReplaceAll(c.msg, "\n", "\n"+strings.Repeat("    ", c.depth+1)))
+			sb.WriteByte('\n')
+		}
+	}
+	if e.Stack != "" {
+		for _, line := range strings.Split(e.Stack, "\n") {
+			sb.WriteString("    ")
+			sb.WriteString(line)
+			sb.WriteByte('\n')
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestLoggerErrorChain tests rendering of wrapped and joined error causes
+func TestLoggerErrorChain(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+	inner := errors.New("connection refused")
+	wrapped := fmt.Errorf("read tag: %w", inner)
+
+	logger.Errorln("plc:", wrapped)
+	expected := "0001/01/01 00:00:00.000000 ERROR: TEST plc: read tag: connection refused\n" +
+		"    caused by: connection refused\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	buf.Reset()
+	logger.ErrorKV("shutdown", Err(errors.Join(wrapped, errors.New("timeout"))))
+	expected = "0001/01/01 00:00:00.000000 ERROR: TEST shutdown error=\"read tag: connection refused\\ntimeout\"\n" +
+		"    caused by: read tag: connection refused\n" +
+		"        caused by: connection refused\n" +
+		"    caused by: timeout\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	buf.Reset()
+	logger.Errorln("plain", errors.New("no cause"), (*TestStruct)(nil))
+	expected = "0001/01/01 00:00:00.000000 ERROR: TEST plain no cause <nil *logger.TestStruct at arg 2>\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestJSONErrorChain tests that JSON output carries the chain as an array
+func TestJSONErrorChain(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithEncoder(JSONEncoder{}))
+
+	logger.Error("failed: %v", fmt.Errorf("outer: %w", errors.New("inner")))
+
+	var decoded struct {
+		Errors [][]string `json:"errors"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", buf.String(), err)
+	}
+	if len(decoded.Errors) != 1 || strings.Join(decoded.Errors[0], "|") != "outer: inner|inner" {
+		t.Errorf("Unexpected errors %v", decoded.Errors)
+	}
+}
+
+// TestLoggerStackTrace tests that stack traces start at the call site and respect the level
+func TestLoggerStackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithStackTrace(LogLevelError))
+
+	logger.Warnln("no stack")
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 WARN: TEST no stack\n" {
+		t.Errorf("Expected no stack below ERROR, got: %q", output)
+	}
+
+	buf.Reset()
+	logger.Errorln("with stack")
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("Expected stack lines, got: %q", buf.String())
+	}
+	if !strings.HasSuffix(lines[1], "logger.TestLoggerStackTrace") || !strings.Contains(lines[2], "errors_test.go:") {
+		t.Errorf("Expected stack to start at the test function, got: %q", buf.String())
+	}
+}
+
+// TestErrorChain tests the exported chain helper
+func TestErrorChain(t *testing.T) {
+	err := fmt.Errorf("a: %w", fmt.Errorf("b: %w", errors.New("c")))
+	if chain := strings.Join(ErrorChain(err), "|"); chain != "a: b: c|b: c|c" {
+		t.Errorf("ErrorChain = %q", chain)
+	}
+	if chain := ErrorChain(nil); len(chain) != 0 {
+		t.Errorf("ErrorChain(nil) = %q", chain)
+	}
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// exitFlushTimeout bounds how long Fatal waits for queued lines before exiting
+const exitFlushTimeout = 5 * time.Second
+
+var (
+	exitHandlersMu sync.Mutex
+	exitHandlers   []func()
+)
+
+// RegisterExitHandler adds a function run by Fatal and Fatalln before the process exits.
+// Handlers run in registration order; a panicking handler does not stop the others.
+func RegisterExitHandler(fn func()) {
+	exitHandlersMu.Lock()
+	defer exitHandlersMu.Unlock()
+	exitHandlers = append(exitHandlers, fn)
+}
+
+// WithExitFunc replaces os.Exit as the function Fatal calls, mainly for tests
+func WithExitFunc(fn func(code int)) LoggerOption {
+	return func(l *Logger) {
+		l.exitFunc = fn
+	}
+}
+
+// Fatal logs a formatted message at FATAL level, runs the exit handlers,
+// flushes the sinks and exits with status 1
+func (l *Logger) Fatal(format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelFatal {
+		l.log(LogLevelFatal, nil, format, v...)
+	}
+	l.exit()
+}
+
+// Fatalln logs a space-separated list of values at FATAL level, runs the exit
+// handlers, flushes the sinks and exits with status 1
+func (l *Logger) Fatalln(v ...interface{}) {
+	if l.GetLevel() <= LogLevelFatal {
+		l.logln(LogLevelFatal, nil, v...)
+	}
+	l.exit()
+}
+
+// FatalKV logs a message with structured key/value fields at FATAL level, runs
+// the exit handlers, flushes the sinks and exits with status 1
+func (l *Logger) FatalKV(msg string, kv ...interface{}) {
+	if l.GetLevel() <= LogLevelFatal {
+		l.logln(LogLevelFatal, fieldsFromKV(kv), msg)
+	}
+	l.exit()
+}
+
+// Panic logs a formatted message at PANIC level, then panics with the message
+func (l *Logger) Panic(format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelPanic {
+		l.log(LogLevelPanic, nil, format, v...)
+	}
+	l.flushAll()
+	panic(fmt.Sprintf(format, v...))
+}
+
+// Panicln logs a space-separated list of values at PANIC level, then panics with the message
+func (l *Logger) Panicln(v ...interface{}) {
+	if l.GetLevel() <= LogLevelPanic {
+		l.logln(LogLevelPanic, nil, v...)
+	}
+	l.flushAll()
+	message, _ := l.formatArgs(v...)
+	panic(message)
+}
+
+// PanicKV logs a message with structured key/value fields at PANIC level, then panics with the message
+func (l *Logger) PanicKV(msg string, kv ...interface{}) {
+	if l.GetLevel() <= LogLevelPanic {
+		l.logln(LogLevelPanic, fieldsFromKV(kv), msg)
+	}
+	l.flushAll()
+	panic(msg)
+}
+
+// exit runs the exit handlers, flushes everything written so far and exits
+func (l *Logger) exit() {
+	runExitHandlers()
+	l.flushAll()
+	l.exitFunc(1)
+}
+
+// flushAll writes pending duplicate summaries and queued lines, waiting at most
+// exitFlushTimeout, and syncs the sinks, so nothing is lost if the process dies
+func (l *Logger) flushAll() {
+	if l.dedup != nil {
+		l.dedup.flush()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exitFlushTimeout)
+	l.Flush(ctx)
+	cancel()
+	l.syncSinks()
+}
+
+func runExitHandlers() {
+	exitHandlersMu.Lock()
+	handlers := append([]func(){}, exitHandlers...)
+	exitHandlersMu.Unlock()
+
+	for _, fn := range handlers {
+		func() {
+			defer func() {
+				recover()
+			}()
+			fn()
+		}()
+	}
+}
+
+// syncSinks commits sink writers that support it, such as files, to stable storage
+func (l *Logger) syncSinks() {
+	for _, s := range l.sinks {
+		if syncer, ok := s.writer.(interface{ Sync() error }); ok {
+			s.mu.Lock()
+			syncer.Sync()
+			s.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// syncBuffer records whether Sync was called
+type syncBuffer struct {
+	bytes.Buffer
+	synced bool
+}
+
+func (b *syncBuffer) Sync() error {
+	b.synced = true
+	return nil
+}
+
+// TestLoggerFatal tests that Fatal logs, runs exit handlers, flushes and exits with status 1
+func TestLoggerFatal(t *testing.T) {
+	var buf syncBuffer
+	var exitCode = -1
+	var order []string
+
+	RegisterExitHandler(func() { order = append(order, "handler") })
+	RegisterExitHandler(func() { panic("broken handler") })
+	defer func() { exitHandlers = nil }()
+
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithAsync(8, AsyncBlock), WithExitFunc(func(code int) {
+		order = append(order, "exit")
+		exitCode = code
+	}))
+
+	logger.Fatal("cannot open %s", "config.yaml")
+
+	expected := "0001/01/01 00:00:00.000000 FATAL: TEST cannot open config.yaml\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+	if exitCode != 1 {
+		t.Errorf("Exit code %d, want 1", exitCode)
+	}
+	if len(order) != 2 || order[0] != "handler" || order[1] != "exit" {
+		t.Errorf("Unexpected call order %v", order)
+	}
+	if !buf.synced {
+		t.Error("Expected writer to be synced before exit")
+	}
+	logger.Close()
+}
+
+// TestLoggerFatalSuppressed tests that Fatal still exits when the level hides the line
+func TestLoggerFatalSuppressed(t *testing.T) {
+	var buf bytes.Buffer
+	exited := false
+	logger := NewLogger("TEST", WithLevel(LogLevelFatal+1), WithWriter(&buf), WithExitFunc(func(int) { exited = true }))
+
+	logger.Fatalln("hidden")
+	if buf.Len() != 0 || !exited {
+		t.Errorf("Expected silent exit, got output %q exited=%v", buf.String(), exited)
+	}
+}
+
+// TestLoggerPanic tests that Panic logs and then panics with the message
+func TestLoggerPanic(t *testing.T) {
+	testCases := []struct {
+		name     string
+		log      func(l *Logger)
+		message  string
+		expected string
+	}{
+		{
+			name:     "Panic",
+			log:      func(l *Logger) { l.Panic("bad state %d", 7) },
+			message:  "bad state 7",
+			expected: "0001/01/01 00:00:00.000000 PANIC: TEST bad state 7\n",
+		},
+		{
+			name:     "Panicln",
+			log:      func(l *Logger) { l.Panicln("bad", "state", nil) },
+			message:  "bad state <nil arg 2>",
+			expected: "0001/01/01 00:00:00.000000 PANIC: TEST bad state <nil arg 2>\n",
+		},
+		{
+			name:     "PanicKV",
+			log:      func(l *Logger) { l.PanicKV("bad state", "id", 7) },
+			message:  "bad state",
+			expected: "0001/01/01 00:00:00.000000 PANIC: TEST bad state id=7\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+			defer func() {
+				r := recover()
+				if r != tc.message {
+					t.Errorf("Recovered %v, want %q", r, tc.message)
+				}
+				if output := buf.String(); output != tc.expected {
+					t.Errorf("Expected output %q, got: %q", tc.expected, output)
+				}
+			}()
+			tc.log(logger)
+		})
+	}
+}
+
+// slowWriter delays every write, so async lines stay queued for a while
+type slowWriter struct {
+	lockedBuffer
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(20 * time.Millisecond)
+	return w.lockedBuffer.Write(p)
+}
+
+// TestLoggerPanicAsync tests that the PANIC line is written before panicking
+func TestLoggerPanicAsync(t *testing.T) {
+	var w slowWriter
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&w), WithAsync(16, AsyncBlock))
+	defer logger.Close()
+
+	defer func() {
+		recover()
+		expected := "0001/01/01 00:00:00.000000 INFO: TEST before\n" +
+			"0001/01/01 00:00:00.000000 PANIC: TEST bad state\n"
+		if output := w.String(); output != expected {
+			t.Errorf("Expected output %q, got: %q", expected, output)
+		}
+	}()
+	logger.Infoln("before")
+	logger.Panicln("bad state")
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// badKey is used for values in a key/value list that have no usable string key
+const badKey = "!BADKEY"
+
+// Field is a single structured key/value pair attached to a log line
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a Field holding a string value
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int returns a Field holding an int value
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64 returns a Field holding an int64 value
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Uint64 returns a Field holding a uint64 value
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64 returns a Field holding a float64 value
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool returns a Field holding a bool value
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration returns a Field holding a time.Duration value
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Time returns a Field holding a time.Time value
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err returns a Field holding an error under the "error" key
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// Any returns a Field holding an arbitrary value
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// fieldsFromKV converts a mixed list of Field values and alternating key/value
+// pairs into fields. Values without a usable string key are kept under badKey.
+func fieldsFromKV(kv []interface{}) []Field {
+	if len(kv) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, len(kv)/2+1)
+	for i := 0; i < len(kv); i++ {
+		switch k := kv[i].(type) {
+		case Field:
+			fields = append(fields, k)
+		case []Field:
+			fields = append(fields, k...)
+		case string:
+			if i+1 >= len(kv) {
+				fields = append(fields, Field{Key: badKey, Value: k})
+				continue
+			}
+			fields = append(fields, Field{Key: k, Value: kv[i+1]})
+			i++
+		default:
+			fields = append(fields, Field{Key: badKey, Value: k})
+		}
+	}
+	return fields
+}
+
+// typedNil reports whether arg is a nil pointer, slice or map wrapped in a non-nil interface
+func typedNil(arg interface{}) bool {
+	val := reflect.ValueOf(arg)
+	return (val.Kind() == reflect.Ptr || val.Kind() == reflect.Slice || val.Kind() == reflect.Map) && val.IsNil()
+}
+
+// formatFieldValue renders a field value with the same nil handling as formatArgs
+func formatFieldValue(v interface{}) string {
+	if v == nil {
+		return "<nil>"
+	}
+	if typedNil(v) {
+		return fmt.Sprintf("<nil %s>", reflect.TypeOf(v))
+	}
+	return FormatArgIntoString(v)
+}
+
+// needsQuoting reports whether a rendered value must be quoted to stay a single token
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// appendFields renders fields as space separated key=value pairs
+func appendFields(sb *strings.Builder, fields []Field) {
+	for _, f := range fields {
+		value := formatFieldValue(f.Value)
+		if needsQuoting(value) {
+			value = strconv.Quote(value)
+		}
+		sb.WriteByte(' ')
+		sb.WriteString(f.Key)
+		sb.WriteByte('=')
+		sb.WriteString(value)
+	}
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestLoggerKV tests that structured fields are rendered after the message
+func TestLoggerKV(t *testing.T) {
+	testCases := []struct {
+		name     string
+		msg      string
+		kv       []interface{}
+		expected string
+	}{
+		{
+			name:     "Alternating pairs",
+			msg:      "request done",
+			kv:       []interface{}{"user", 42, "bytes", 1024},
+			expected: "0001/01/01 00:00:00.000000 INFO: TEST request done user=42 bytes=1024\n",
+		},
+		{
+			name:     "Typed fields",
+			msg:      "typed",
+			kv:       []interface{}{String("name", "plc"), Duration("took", 1500*time.Millisecond), Bool("ok", true)},
+			expected: "0001/01/01 00:00:00.000000 INFO: TEST typed name=plc took=1.5s ok=true\n",
+		},
+		{
+			name:     "Quoted values",
+			msg:      "quoted",
+			kv:       []interface{}{"text", "hello world", "empty", "", "eq", "a=b"},
+			expected: "0001/01/01 00:00:00.000000 INFO: TEST quoted text=\"hello world\" empty=\"\" eq=\"a=b\"\n",
+		},
+		{
+			name:     "Nil values",
+			msg:      "nils",
+			kv:       []interface{}{"a", nil, "b", (*TestStruct)(nil), Err(nil)},
+			expected: "0001/01/01 00:00:00.000000 INFO: TEST nils a=<nil> b=\"<nil *logger.TestStruct>\" error=<nil>\n",
+		},
+		{
+			name:     "Error field",
+			msg:      "failed",
+			kv:       []interface{}{Err(errors.New("boom"))},
+			expected: "0001/01/01 00:00:00.000000 INFO: TEST failed error=boom\n",
+		},
+		{
+			name:     "Dangling key and bad key",
+			msg:      "bad",
+			kv:       []interface{}{123, "dangling"},
+			expected: "0001/01/01 00:00:00.000000 INFO: TEST bad !BADKEY=123 !BADKEY=dangling\n",
+		},
+		{
+			name:     "Panicking String method",
+			msg:      "panic",
+			kv:       []interface{}{"v", &TestPanicStruct{}},
+			expected: "0001/01/01 00:00:00.000000 INFO: TEST panic v=\"%!v(PANIC=String method: hello world)\"\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithLevel(LogLevelDebug), WithZeroTime(), WithWriter(&buf))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf.Reset()
+			logger.InfoKV(tc.msg, tc.kv...)
+
+			if output := buf.String(); output != tc.expected {
+				t.Errorf("Expected output %q, got: %q", tc.expected, output)
+			}
+		})
+	}
+}
+
+// TestLoggerKVLevels tests that KV methods respect the log level
+func TestLoggerKVLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithLevel(LogLevelWarn), WithZeroTime(), WithWriter(&buf))
+
+	logger.DebugKV("debug", "k", 1)
+	logger.InfoKV("info", "k", 2)
+	logger.WarnKV("warn", "k", 3)
+	logger.LogKV(LogLevelError, "error", "k", 4)
+
+	expected := "0001/01/01 00:00:00.000000 WARN: TEST warn k=3\n" +
+		"0001/01/01 00:00:00.000000 ERROR: TEST error k=4\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
@@ -0,0 +1,56 @@
+package logger
+
+import "errors"
+
+// ErrDropEntry can be returned by a hook to veto the log line
+var ErrDropEntry = errors.New("logger: drop entry")
+
+// HookFunc is called with every entry at one of its levels before it is encoded.
+// It may modify the entry, return ErrDropEntry to discard the line, or return
+// any other error to have it passed to the error handler.
+type HookFunc func(e *Entry) error
+
+// hook is a registered HookFunc with the levels it fires for
+type hook struct {
+	levels []LogLevel
+	fn     HookFunc
+}
+
+// AddHook registers fn for the given levels, or for every level when none are given.
+// Hooks run outside the writer lock, so a hook may itself log. Loggers derived
+// afterwards via With and Named inherit the hook.
+func (l *Logger) AddHook(levels []LogLevel, fn HookFunc) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook{levels: append([]LogLevel(nil), levels...), fn: fn})
+}
+
+// fires reports whether the hook is registered for level
+func (h hook) fires(level LogLevel) bool {
+	if len(h.levels) == 0 {
+		return true
+	}
+	for _, lv := range h.levels {
+		if lv == level {
+			return true
+		}
+	}
+	return false
+}
+
+// runHooks calls the hooks registered for the entry's level, reporting
+// whether the entry survived
+func (l *Logger) runHooks(hooks []hook, e *Entry) bool {
+	for _, h := range hooks {
+		if !h.fires(e.Level) {
+			continue
+		}
+		if err := h.fn(e); err != nil {
+			if errors.Is(err, ErrDropEntry) {
+				return false
+			}
+			l.reportError(err)
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoggerHooks tests level selection, mutation and veto of entries
+func TestLoggerHooks(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+	errorCount := 0
+	logger.AddHook([]LogLevel{LogLevelError}, func(e *Entry) error {
+		errorCount++
+		return nil
+	})
+	logger.AddHook(nil, func(e *Entry) error {
+		if strings.Contains(e.Message, "secret") {
+			return ErrDropEntry
+		}
+		e.Fields = append(e.Fields, String("host", "plc1"))
+		return nil
+	})
+
+	logger.Infoln("hello")
+	logger.Errorln("broken")
+	logger.Warnln("secret token")
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST hello host=plc1\n" +
+		"0001/01/01 00:00:00.000000 ERROR: TEST broken host=plc1\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+	if errorCount != 1 {
+		t.Errorf("Error hook called %d times, want 1", errorCount)
+	}
+}
+
+// TestLoggerHookCanLog tests that a hook may log without deadlocking and that errors are reported
+func TestLoggerHookCanLog(t *testing.T) {
+	var buf bytes.Buffer
+	var reported []error
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithErrorHandler(func(err error) {
+		reported = append(reported, err)
+	}))
+
+	logger.AddHook([]LogLevel{LogLevelError}, func(e *Entry) error {
+		logger.Infoln("alert for:", e.Message)
+		return errors.New("pager unavailable")
+	})
+	logger.Errorln("outage")
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST alert for: outage\n" +
+		"0001/01/01 00:00:00.000000 ERROR: TEST outage\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+	if len(reported) != 1 || reported[0].Error() != "pager unavailable" {
+		t.Errorf("Expected hook error to be reported, got %v", reported)
+	}
+}
+
+// TestLoggerHookCaller tests that hooks see the user's call site
+func TestLoggerHookCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithWriter(&buf))
+
+	var callers []Caller
+	logger.AddHook(nil, func(e *Entry) error {
+		callers = append(callers, e.Caller)
+		return nil
+	})
+	logger.With("k", "v").Info("formatted")
+	logger.Infoln("plain")
+	logger.InfoKV("kv")
+
+	if len(callers) != 3 {
+		t.Fatalf("Expected 3 callers, got %d", len(callers))
+	}
+	for _, c := range callers {
+		if filepath.Base(c.File) != "hook_test.go" || !strings.HasSuffix(c.Function, "TestLoggerHookCaller") {
+			t.Errorf("Unexpected caller %+v", c)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// JSONEncoder renders each entry as a single JSON object per line with the keys
+// "time" (a number for TimeFormatEpochMillis, omitted for TimeFormatNone),
+// "level", "prefix", "msg", optionally "delta", "caller" and "func",
+// followed by the fields, "errors" holding the chain of each wrapped error as an
+// array, and "stack".
+// ANSI escape sequences are stripped from the prefix.
+type JSONEncoder struct{}
+
+// Encode implements Encoder
+func (JSONEncoder) Encode(e *Entry) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	if ts, ok := formatEntryTime(e, time.RFC3339Nano); ok {
+		sb.WriteString(`"time":`)
+		if e.TimeFormat == TimeFormatEpochMillis {
+			sb.WriteString(ts)
+		} else {
+			appendJSONString(&sb, ts)
+		}
+		sb.WriteByte(',')
+	}
+	sb.WriteString(`"level":`)
+	appendJSONString(&sb, e.Level.String())
+	sb.WriteString(`,"prefix":`)
+	appendJSONString(&sb, coloransi.Strip(e.Prefix))
+	sb.WriteString(`,"msg":`)
+	appendJSONString(&sb, e.Message)
+	if e.HasDeltaTime {
+		sb.WriteString(`,"delta":`)
+		appendJSONString(&sb, e.DeltaTime.String())
+	}
+	if e.Caller.File != "" {
+		sb.WriteString(`,"caller":`)
+		appendJSONString(&sb, e.Caller.String())
+		if e.Caller.Function != "" {
+			sb.WriteString(`,"func":`)
+			appendJSONString(&sb, e.Caller.ShortFunction())
+		}
+	}
+	for _, f := range e.Fields {
+		sb.WriteByte(',')
+		appendJSONString(&sb, f.Key)
+		sb.WriteByte(':')
+		appendJSONValue(&sb, f.Value)
+	}
+	appendJSONErrors(&sb, e.Errors)
+	if e.Stack != "" {
+		sb.WriteString(`,"stack":`)
+		appendJSONString(&sb, e.Stack)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// appendJSONErrors writes the chain of every wrapped error as an array of messages
+func appendJSONErrors(sb *strings.Builder, errs []error) {
+	written := 0
+	for _, err := range errs {
+		chain := ErrorChain(err)
+		if len(chain) < 2 {
+			continue
+		}
+
+		if written == 0 {
+			sb.WriteString(`,"errors":[`)
+		} else {
+			sb.WriteByte(',')
+		}
+		written++
+
+		sb.WriteByte('[')
+		for i, msg := range chain {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			appendJSONString(sb, msg)
+		}
+		sb.WriteByte(']')
+	}
+	if written > 0 {
+		sb.WriteByte(']')
+	}
+}
+
+// appendJSONValue renders a field value as JSON, falling back to its string form
+func appendJSONValue(sb *strings.Builder, v interface{}) {
+	if v == nil || typedNil(v) {
+		sb.WriteString("null")
+		return
+	}
+
+	switch val := v.(type) {
+	case string:
+		appendJSONString(sb, val)
+	case bool:
+		sb.WriteString(strconv.FormatBool(val))
+	case int:
+		sb.WriteString(strconv.FormatInt(int64(val), 10))
+	case int8:
+		sb.WriteString(strconv.FormatInt(int64(val), 10))
+	case int16:
+		sb.WriteString(strconv.FormatInt(int64(val), 10))
+	case int32:
+		sb.WriteString(strconv.FormatInt(int64(val), 10))
+	case int64:
+		sb.WriteString(strconv.FormatInt(val, 10))
+	case uint:
+		sb.WriteString(strconv.FormatUint(uint64(val), 10))
+	case uint8:
+		sb.WriteString(strconv.FormatUint(uint64(val), 10))
+	case uint16:
+		sb.WriteString(strconv.FormatUint(uint64(val), 10))
+	case uint32:
+		sb.WriteString(strconv.FormatUint(uint64(val), 10))
+	case uint64:
+		sb.WriteString(strconv.FormatUint(val, 10))
+	case float32:
+		appendJSONFloat(sb, float64(val), 32)
+	case float64:
+		appendJSONFloat(sb, val, 64)
+	case time.Time:
+		appendJSONString(sb, val.Format(time.RFC3339Nano))
+	case error, fmt.Stringer:
+		appendJSONString(sb, FormatArgIntoString(val))
+	default:
+		appendJSONMarshal(sb, val)
+	}
+}
+
+// appendJSONFloat renders a float, quoting values JSON cannot represent
+func appendJSONFloat(sb *strings.Builder, f float64, bitSize int) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		appendJSONString(sb, strconv.FormatFloat(f, 'g', -1, bitSize))
+		return
+	}
+	sb.WriteString(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+// appendJSONMarshal renders v with encoding/json, using its string form if that fails
+func appendJSONMarshal(sb *strings.Builder, v interface{}) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+		err = enc.Encode(v)
+	}()
+	if err != nil {
+		appendJSONString(sb, FormatArgIntoString(v))
+		return
+	}
+	sb.Write(bytes.TrimRight(buf.Bytes(), "\n"))
+}
+
+// appendJSONString writes s as a quoted JSON string. Control characters are
+// escaped and invalid UTF-8 bytes are replaced with U+FFFD.
+func appendJSONString(sb *strings.Builder, s string) {
+	const hex = "0123456789abcdef"
+
+	sb.WriteByte('"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch c {
+			case '"', '\\':
+				sb.WriteByte('\\')
+				sb.WriteByte(c)
+			case '\n':
+				sb.WriteString(`\n`)
+			case '\r':
+				sb.WriteString(`\r`)
+			case '\t':
+				sb.WriteString(`\t`)
+			default:
+				if c < 0x20 {
+					sb.WriteString(`\u00`)
+					sb.WriteByte(hex[c>>4])
+					sb.WriteByte(hex[c&0xF])
+				} else {
+					sb.WriteByte(c)
+				}
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			sb.WriteString(`\ufffd`)
+		} else {
+			sb.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	sb.WriteByte('"')
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// TestJSONEncoder tests the exact JSON layout produced for a log line
+func TestJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	prefix := coloransi.Color(coloransi.BrightWhite, coloransi.Blue, "global")
+	logger := NewLogger(prefix, WithZeroTime(), WithWriter(&buf), WithEncoder(JSONEncoder{}))
+
+	logger.InfoKV("line1\nline2 \"quoted\"", "user", 42, "ok", true, "ratio", 0.5, "err", errors.New("boom"), "missing", nil)
+
+	expected := `{"time":"0001-01-01T00:00:00Z","level":"INFO","prefix":"global","msg":"line1\nline2 \"quoted\"",` +
+		`"user":42,"ok":true,"ratio":0.5,"err":"boom","missing":null}` + "\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestJSONEncoderValid tests that awkward values still produce valid JSON
+func TestJSONEncoderValid(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithWriter(&buf), WithEncoder(JSONEncoder{}), WithDeltaTime(true))
+
+	logger.Infoln("bad utf8 \xff\xfe", "\x01control", "<html>&")
+	logger.InfoKV("values",
+		"nan", math.NaN(),
+		"inf", math.Inf(1),
+		"dur", 2*time.Second,
+		"when", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"map", map[string]int{"a": 1},
+		"chan", make(chan int),
+		"nilptr", (*TestStruct)(nil),
+		"panic", &TestPanicStruct{},
+	)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var first map[string]interface{}
+	if err := json.Unmarshal(lines[0], &first); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", lines[0], err)
+	}
+	if msg := first["msg"]; msg != "bad utf8 �� \x01control <html>&" {
+		t.Errorf("Unexpected msg %q", msg)
+	}
+	if _, ok := first["delta"]; !ok {
+		t.Errorf("Expected delta key in %q", lines[0])
+	}
+
+	var second map[string]interface{}
+	if err := json.Unmarshal(lines[1], &second); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", lines[1], err)
+	}
+	expected := map[string]interface{}{
+		"nan":    "NaN",
+		"inf":    "+Inf",
+		"dur":    "2s",
+		"when":   "2024-01-02T03:04:05Z",
+		"nilptr": nil,
+		"panic":  "%!v(PANIC=String method: hello world)",
+	}
+	for key, want := range expected {
+		if got := second[key]; got != want {
+			t.Errorf("Key %q = %v, want %v", key, got, want)
+		}
+	}
+	if m, ok := second["map"].(map[string]interface{}); !ok || m["a"] != float64(1) {
+		t.Errorf("Unexpected map value %v", second["map"])
+	}
+	if _, ok := second["chan"].(string); !ok {
+		t.Errorf("Expected unmarshalable chan to fall back to a string, got %v", second["chan"])
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// levelInfo describes a registered level
+type levelInfo struct {
+	name  string
+	color coloransi.ColorCode
+}
+
+var (
+	levelsMu sync.RWMutex
+
+	// levels holds every known level, built-in and custom
+	levels = map[LogLevel]levelInfo{
+		LogLevelTrace: {"TRACE", coloransi.BrightBlack},
+		LogLevelDebug: {"DEBUG", coloransi.White},
+		LogLevelInfo:  {"INFO", coloransi.Green},
+		LogLevelWarn:  {"WARN", coloransi.Yellow},
+		LogLevelError: {"ERROR", coloransi.Red},
+		LogLevelPanic: {"PANIC", coloransi.BrightRed},
+		LogLevelFatal: {"FATAL", coloransi.BrightMagenta},
+	}
+
+	// levelNames maps upper-case names back to levels
+	levelNames = map[string]LogLevel{
+		"TRACE": LogLevelTrace,
+		"DEBUG": LogLevelDebug,
+		"INFO":  LogLevelInfo,
+		"WARN":  LogLevelWarn,
+		"ERROR": LogLevelError,
+		"PANIC": LogLevelPanic,
+		"FATAL": LogLevelFatal,
+	}
+)
+
+// RegisterLevel defines a custom named level at the given severity, e.g.
+// RegisterLevel(LogLevel(10), "AUDIT", coloransi.Cyan). Levels compare by
+// severity like the built-in ones, so SetLevel filtering works unchanged.
+func RegisterLevel(level LogLevel, name string, color coloransi.ColorCode) error {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "" {
+		return fmt.Errorf("logger: level name must not be empty")
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	if existing, ok := levels[level]; ok {
+		return fmt.Errorf("logger: level %d is already registered as %s", level, existing.name)
+	}
+	if existing, ok := levelNames[name]; ok {
+		return fmt.Errorf("logger: level name %s is already used by level %d", name, existing)
+	}
+
+	levels[level] = levelInfo{name: name, color: color}
+	levelNames[name] = level
+	return nil
+}
+
+// LevelByName looks up a built-in or registered level by its case-insensitive name
+func LevelByName(name string) (LogLevel, bool) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	level, ok := levelNames[strings.ToUpper(strings.TrimSpace(name))]
+	return level, ok
+}
+
+// LevelColor returns the color associated with a level, White when unknown
+func LevelColor(level LogLevel) coloransi.ColorCode {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	if info, ok := levels[level]; ok {
+		return info.color
+	}
+	return coloransi.White
+}
+
+// customLevelName returns the name of a registered level
+func customLevelName(level LogLevel) (string, bool) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	info, ok := levels[level]
+	return info.name, ok
+}
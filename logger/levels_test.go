@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// TestLoggerTrace tests the TRACE level below DEBUG
+func TestLoggerTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+	logger.Traceln("hidden")
+	logger.SetLevel(LogLevelTrace)
+	logger.Trace("visible %d", 1)
+	logger.TraceKV("kv", "k", 2)
+
+	expected := "0001/01/01 00:00:00.000000 TRACE: TEST visible 1\n" +
+		"0001/01/01 00:00:00.000000 TRACE: TEST kv k=2\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestCustomLevels tests registering, naming, looking up and filtering custom levels
+func TestCustomLevels(t *testing.T) {
+	audit := LogLevel(10)
+	notice := LogLevel(-5)
+	t.Cleanup(func() {
+		levelsMu.Lock()
+		defer levelsMu.Unlock()
+		delete(levels, audit)
+		delete(levels, notice)
+		delete(levelNames, "AUDIT")
+		delete(levelNames, "NOTICE")
+	})
+	if err := RegisterLevel(audit, "audit", coloransi.Cyan); err != nil {
+		t.Fatalf("RegisterLevel returned %v", err)
+	}
+	if err := RegisterLevel(notice, "NOTICE", coloransi.Blue); err != nil {
+		t.Fatalf("RegisterLevel returned %v", err)
+	}
+
+	if err := RegisterLevel(audit, "OTHER", coloransi.Cyan); err == nil {
+		t.Error("Expected error registering a taken level")
+	}
+	if err := RegisterLevel(LogLevel(11), "warn", coloransi.Cyan); err == nil {
+		t.Error("Expected error registering a taken name")
+	}
+	if err := RegisterLevel(LogLevel(12), " ", coloransi.Cyan); err == nil {
+		t.Error("Expected error registering an empty name")
+	}
+
+	if name := audit.String(); name != "AUDIT" {
+		t.Errorf("String() = %q, want AUDIT", name)
+	}
+	if name := LogLevel(99).String(); name != "UNKNOWN" {
+		t.Errorf("String() = %q, want UNKNOWN", name)
+	}
+	if level, ok := LevelByName("Audit"); !ok || level != audit {
+		t.Errorf("LevelByName(Audit) = %v, %v", level, ok)
+	}
+	if level, ok := LevelByName("debug"); !ok || level != LogLevelDebug {
+		t.Errorf("LevelByName(debug) = %v, %v", level, ok)
+	}
+	if color := LevelColor(audit); color != coloransi.Cyan {
+		t.Errorf("LevelColor(audit) = %v, want %v", color, coloransi.Cyan)
+	}
+
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithLevel(LogLevelError), WithZeroTime(), WithWriter(&buf))
+	logger.Logln(notice, "hidden")
+	logger.Logf(audit, "user %s logged in", "bob")
+	logger.LogKV(audit, "export", "rows", 3)
+
+	expected := "0001/01/01 00:00:00.000000 AUDIT: TEST user bob logged in\n" +
+		"0001/01/01 00:00:00.000000 AUDIT: TEST export rows=3\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
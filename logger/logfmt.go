@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// LogfmtEncoder renders each entry as a logfmt line:
+// ts=... level=info prefix=global msg="..." key=value
+// Values containing spaces, equals signs, quotes or control characters are
+// quoted and ANSI escape sequences are stripped from the prefix.
+type LogfmtEncoder struct{}
+
+// Encode implements Encoder
+func (LogfmtEncoder) Encode(e *Entry) string {
+	var sb strings.Builder
+	if ts, ok := formatEntryTime(e, time.RFC3339Nano); ok {
+		appendLogfmtPair(&sb, "ts", ts)
+		sb.WriteByte(' ')
+	}
+	appendLogfmtPair(&sb, "level", strings.ToLower(e.Level.String()))
+	sb.WriteByte(' ')
+	appendLogfmtPair(&sb, "prefix", coloransi.Strip(e.Prefix))
+	sb.WriteByte(' ')
+	appendLogfmtPair(&sb, "msg", e.Message)
+	if e.HasDeltaTime {
+		sb.WriteByte(' ')
+		appendLogfmtPair(&sb, "delta", e.DeltaTime.String())
+	}
+	if e.Caller.File != "" {
+		sb.WriteByte(' ')
+		appendLogfmtPair(&sb, "caller", e.Caller.String())
+		if e.Caller.Function != "" {
+			sb.WriteByte(' ')
+			appendLogfmtPair(&sb, "func", e.Caller.ShortFunction())
+		}
+	}
+	for _, f := range e.Fields {
+		sb.WriteByte(' ')
+		appendLogfmtPair(&sb, logfmtKey(f.Key), formatFieldValue(f.Value))
+	}
+	if e.Stack != "" {
+		sb.WriteByte(' ')
+		appendLogfmtPair(&sb, "stack", e.Stack)
+	}
+	sb.WriteByte('\n')
+	return sb.String()
+}
+
+// appendLogfmtPair writes key=value, quoting the value when required
+func appendLogfmtPair(sb *strings.Builder, key, value string) {
+	sb.WriteString(key)
+	sb.WriteByte('=')
+	if needsQuoting(value) {
+		sb.WriteString(strconv.Quote(value))
+		return
+	}
+	sb.WriteString(value)
+}
+
+// logfmtKey replaces characters that cannot appear in a bare logfmt key
+func logfmtKey(key string) string {
+	if key == "" {
+		return badKey
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, key)
+}
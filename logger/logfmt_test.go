@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// TestLogfmtEncoder tests logfmt output through the regular logging methods
+func TestLogfmtEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	prefix := coloransi.Color(coloransi.BrightWhite, coloransi.Blue, "global")
+	logger := NewLogger(prefix, WithZeroTime(), WithWriter(&buf), WithEncoder(LogfmtEncoder{}))
+
+	testCases := []struct {
+		name     string
+		log      func()
+		expected string
+	}{
+		{
+			name:     "Formatted",
+			log:      func() { logger.Info("%d items", 3) },
+			expected: "ts=0001-01-01T00:00:00Z level=info prefix=global msg=\"3 items\"\n",
+		},
+		{
+			name:     "Single word",
+			log:      func() { logger.Warnln("disk") },
+			expected: "ts=0001-01-01T00:00:00Z level=warn prefix=global msg=disk\n",
+		},
+		{
+			name: "Fields",
+			log: func() {
+				logger.ErrorKV("failed", "path", "/tmp/a b", "q", `say "hi"`, "eq", "a=b", "empty", "", "bad key", 1, "nl", "a\nb")
+			},
+			expected: `ts=0001-01-01T00:00:00Z level=error prefix=global msg=failed path="/tmp/a b" q="say \"hi\"" eq="a=b" empty="" bad_key=1 nl="a\nb"` + "\n",
+		},
+		{
+			name:     "Nil field",
+			log:      func() { logger.DebugKV("nil", "ptr", (*TestStruct)(nil)) },
+			expected: "ts=0001-01-01T00:00:00Z level=debug prefix=global msg=nil ptr=\"<nil *logger.TestStruct>\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf.Reset()
+			tc.log()
+
+			if output := buf.String(); output != tc.expected {
+				t.Errorf("Expected output %q, got: %q", tc.expected, output)
+			}
+		})
+	}
+}
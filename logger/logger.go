@@ -7,15 +7,20 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
 )
 
 // LogLevel defines severity levels for logging
 type LogLevel int
 
-// String returns the string representation of a LogLevel
+// String returns the string representation of a LogLevel, including registered custom levels
 func (l LogLevel) String() string {
 	switch l {
+	case LogLevelTrace:
+		return "TRACE"
 	case LogLevelDebug:
 		return "DEBUG"
 	case LogLevelInfo:
@@ -24,28 +29,67 @@ func (l LogLevel) String() string {
 		return "WARN"
 	case LogLevelError:
 		return "ERROR"
+	case LogLevelPanic:
+		return "PANIC"
+	case LogLevelFatal:
+		return "FATAL"
 	default:
+		if name, ok := customLevelName(l); ok {
+			return name
+		}
 		return "UNKNOWN"
 	}
 }
 
 // Log levels in ascending order of severity
 const (
-	LogLevelDebug LogLevel = iota
+	LogLevelTrace LogLevel = iota - 1
+	LogLevelDebug
 	LogLevelInfo
 	LogLevelWarn
 	LogLevelError
+	LogLevelPanic
+	LogLevelFatal
 )
 
 // Logger provides a simple space-delimited logging capability with prefixes and levels
 type Logger struct {
 	writer        io.Writer
+	encoder       Encoder
 	level         LogLevel
+	baseLevel     LogLevel // level before registry overrides, see DefaultLevel
 	createTime    time.Time
+	clock         Clock
+	timeFormat    string
+	location      *time.Location
 	includeDeltaT bool
 	zeroT         bool
 	prefix        string
+	name          string
+	envSpec       *levelSpec // from WithEnvLevel, matched against name
+	fields        []Field
+	propagate     bool
+	levelParent   *Logger // when set, the level is read from this logger instead
 	mu            sync.RWMutex
+	wmu           *sync.Mutex // serializes writes, shared with derived loggers
+	sinkCfgs      []Sink
+	color         ColorPolicy
+	sinks         []*sink // shared with derived loggers
+	onError       func(error)
+	hooks         []hook
+	caller        bool
+	callerFunc    bool
+	callerSkip    int
+	stackTrace    bool
+	stackLevel    LogLevel
+	exitFunc      func(code int)
+	lines         *uint64 // lines written, shared with derived loggers
+	sampler       *sampler
+	dedup         *dedup
+	tagKeys       []string
+	limits        *rateLimits // Once/EveryN/Every state, shared with derived loggers
+	asyncCfg      *asyncConfig
+	async         *asyncWriter // shared with derived loggers
 }
 
 // LoggerOption defines a functional option for configuring a Logger
@@ -65,40 +109,152 @@ func WithLevel(level LogLevel) LoggerOption {
 	}
 }
 
+// WithEncoder sets the encoder used to render each log line
+func WithEncoder(enc Encoder) LoggerOption {
+	return func(l *Logger) {
+		l.encoder = enc
+	}
+}
+
 func WithWriter(w io.Writer) LoggerOption {
 	return func(l *Logger) {
 		l.writer = w
 	}
 }
 
+// WithLevelPropagation makes loggers derived via With and Named follow this
+// logger's level until their own level is set explicitly
+func WithLevelPropagation(propagate bool) LoggerOption {
+	return func(l *Logger) {
+		l.propagate = propagate
+	}
+}
+
 func WithZeroTime() LoggerOption {
 	return func(l *Logger) {
 		l.zeroT = true
 	}
 }
 
-// NewLogger creates a new Logger with the specified prefix and options
+// NewLogger creates a new Logger with the specified prefix and options.
+// The logger is registered under its prefix without colors unless WithName
+// is given; short-lived loggers should call Unregister when done.
 func NewLogger(prefix string, options ...LoggerOption) *Logger {
 	l := &Logger{
-		writer:     os.Stdout,
-		level:      LogLevelDebug, // Default level
-		prefix:     prefix,
-		createTime: time.Now(),
+		writer:   os.Stdout,
+		encoder:  TextEncoder{},
+		level:    LogLevelDebug, // Default level
+		prefix:   prefix,
+		clock:    systemClock{},
+		wmu:      &sync.Mutex{},
+		exitFunc: os.Exit,
+		lines:    new(uint64),
+		limits:   &rateLimits{},
 	}
 
 	// Apply options
 	for _, option := range options {
 		option(l)
 	}
+	if l.name == "" {
+		l.name = coloransi.Strip(prefix)
+	}
+	l.createTime = l.clock.Now()
+
+	l.register()
+	l.sinks = l.buildSinks()
+	if l.asyncCfg != nil && l.asyncCfg.enabled {
+		l.async = newAsyncWriter(l.asyncCfg, l.writeLine)
+	}
 
 	return l
 }
 
-func (l *Logger) now() time.Time {
+// With returns a derived logger that appends the given fields to every line.
+// kv may mix Field values with alternating string keys and values.
+func (l *Logger) With(kv ...interface{}) *Logger {
+	child := l.derive()
+	child.fields = append(child.fields, fieldsFromKV(kv)...)
+	return child
+}
+
+// Named returns a derived logger whose prefix and registry name are extended with
+// a dotted sub-prefix. The child is registered, so overrides for its name apply.
+func (l *Logger) Named(subprefix string) *Logger {
+	child := l.derive()
+	child.prefix = joinName(child.prefix, subprefix)
+	child.name = joinName(child.name, subprefix)
+	child.registerNamed()
+	return child
+}
+
+// joinName appends a dotted sub-prefix to a prefix or name
+func joinName(base, sub string) string {
+	if base == "" {
+		return sub
+	}
+	if sub == "" {
+		return base
+	}
+	return base + "." + sub
+}
+
+// derive creates a child logger sharing the writer and write lock of l
+func (l *Logger) derive() *Logger {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	child := &Logger{
+		writer:        l.writer,
+		encoder:       l.encoder,
+		level:         l.level,
+		baseLevel:     l.baseLevel,
+		createTime:    l.createTime,
+		clock:         l.clock,
+		timeFormat:    l.timeFormat,
+		location:      l.location,
+		includeDeltaT: l.includeDeltaT,
+		zeroT:         l.zeroT,
+		prefix:        l.prefix,
+		name:          l.name,
+		envSpec:       l.envSpec,
+		fields:        append([]Field(nil), l.fields...),
+		propagate:     l.propagate,
+		wmu:           l.wmu,
+		sinks:         l.sinks,
+		onError:       l.onError,
+		hooks:         append([]hook(nil), l.hooks...),
+		caller:        l.caller,
+		callerFunc:    l.callerFunc,
+		callerSkip:    l.callerSkip,
+		stackTrace:    l.stackTrace,
+		stackLevel:    l.stackLevel,
+		exitFunc:      l.exitFunc,
+		lines:         l.lines,
+		sampler:       l.sampler,
+		dedup:         l.dedup,
+		tagKeys:       l.tagKeys,
+		limits:        l.limits,
+		async:         l.async,
+	}
+	// Follow the direct parent, so SetLevel on any logger in a propagating
+	// tree reaches all of its descendants regardless of creation order
+	if l.propagate || l.levelParent != nil {
+		child.levelParent = l
+	}
+	return child
+}
+
+// timestamp returns a clock reading as written to entries, honouring
+// WithZeroTime and WithLocation
+func (l *Logger) timestamp(t time.Time) time.Time {
 	if l.zeroT {
 		return time.Time{}
 	}
-	return time.Now()
+	if l.location != nil {
+		t = t.In(l.location)
+	}
+	return t
 }
 
 // SetIncludeDeltaTime configures whether to include time since logger creation
@@ -122,135 +278,346 @@ func (l *Logger) GetPrefix() string {
 	return l.prefix
 }
 
-// SetLevel updates the minimum log level
+// LineCount returns the number of lines logged through this logger and the
+// loggers derived from it
+func (l *Logger) LineCount() uint64 {
+	return atomic.LoadUint64(l.lines)
+}
+
+// SetLevel updates the minimum log level. On a derived logger this stops
+// following the parent's level.
 func (l *Logger) SetLevel(level LogLevel) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.level = level
+	l.levelParent = nil
 }
 
 // GetLevel returns the current log level
 func (l *Logger) GetLevel() LogLevel {
 	l.mu.RLock()
-	defer l.mu.RUnlock()
-	return l.level
+	level, parent := l.level, l.levelParent
+	l.mu.RUnlock()
+
+	if parent != nil {
+		return parent.GetLevel()
+	}
+	return level
+}
+
+// Trace logs a formatted message at TRACE level
+func (l *Logger) Trace(format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelTrace {
+		l.log(LogLevelTrace, nil, format, v...)
+	}
 }
 
 // Debug logs a formatted message at DEBUG level
 func (l *Logger) Debug(format string, v ...interface{}) {
 	if l.GetLevel() <= LogLevelDebug {
-		l.log(LogLevelDebug, format, v...)
+		l.log(LogLevelDebug, nil, format, v...)
 	}
 }
 
 // Info logs a formatted message at INFO level
 func (l *Logger) Info(format string, v ...interface{}) {
 	if l.GetLevel() <= LogLevelInfo {
-		l.log(LogLevelInfo, format, v...)
+		l.log(LogLevelInfo, nil, format, v...)
 	}
 }
 
 // Warn logs a formatted message at WARN level
 func (l *Logger) Warn(format string, v ...interface{}) {
 	if l.GetLevel() <= LogLevelWarn {
-		l.log(LogLevelWarn, format, v...)
+		l.log(LogLevelWarn, nil, format, v...)
 	}
 }
 
 // Error logs a formatted message at ERROR level
 func (l *Logger) Error(format string, v ...interface{}) {
 	if l.GetLevel() <= LogLevelError {
-		l.log(LogLevelError, format, v...)
+		l.log(LogLevelError, nil, format, v...)
+	}
+}
+
+// Traceln logs a space-separated list of values at TRACE level
+func (l *Logger) Traceln(v ...interface{}) {
+	if l.GetLevel() <= LogLevelTrace {
+		l.logln(LogLevelTrace, nil, v...)
 	}
 }
 
 // Debugln logs a space-separated list of values at DEBUG level
 func (l *Logger) Debugln(v ...interface{}) {
 	if l.GetLevel() <= LogLevelDebug {
-		l.logln(LogLevelDebug, v...)
+		l.logln(LogLevelDebug, nil, v...)
 	}
 }
 
 // Infoln logs a space-separated list of values at INFO level
 func (l *Logger) Infoln(v ...interface{}) {
 	if l.GetLevel() <= LogLevelInfo {
-		l.logln(LogLevelInfo, v...)
+		l.logln(LogLevelInfo, nil, v...)
 	}
 }
 
 // Warnln logs a space-separated list of values at WARN level
 func (l *Logger) Warnln(v ...interface{}) {
 	if l.GetLevel() <= LogLevelWarn {
-		l.logln(LogLevelWarn, v...)
+		l.logln(LogLevelWarn, nil, v...)
 	}
 }
 
 // Errorln logs a space-separated list of values at ERROR level
 func (l *Logger) Errorln(v ...interface{}) {
 	if l.GetLevel() <= LogLevelError {
-		l.logln(LogLevelError, v...)
+		l.logln(LogLevelError, nil, v...)
 	}
 }
 
-// SimplePrintLines prints each line guarded by a mutex to the writer
+// TraceKV logs a message with structured key/value fields at TRACE level
+func (l *Logger) TraceKV(msg string, kv ...interface{}) {
+	if l.GetLevel() <= LogLevelTrace {
+		l.logln(LogLevelTrace, fieldsFromKV(kv), msg)
+	}
+}
+
+// DebugKV logs a message with structured key/value fields at DEBUG level
+func (l *Logger) DebugKV(msg string, kv ...interface{}) {
+	if l.GetLevel() <= LogLevelDebug {
+		l.logln(LogLevelDebug, fieldsFromKV(kv), msg)
+	}
+}
+
+// InfoKV logs a message with structured key/value fields at INFO level
+func (l *Logger) InfoKV(msg string, kv ...interface{}) {
+	if l.GetLevel() <= LogLevelInfo {
+		l.logln(LogLevelInfo, fieldsFromKV(kv), msg)
+	}
+}
+
+// WarnKV logs a message with structured key/value fields at WARN level
+func (l *Logger) WarnKV(msg string, kv ...interface{}) {
+	if l.GetLevel() <= LogLevelWarn {
+		l.logln(LogLevelWarn, fieldsFromKV(kv), msg)
+	}
+}
+
+// ErrorKV logs a message with structured key/value fields at ERROR level
+func (l *Logger) ErrorKV(msg string, kv ...interface{}) {
+	if l.GetLevel() <= LogLevelError {
+		l.logln(LogLevelError, fieldsFromKV(kv), msg)
+	}
+}
+
+// Logf logs a formatted message at the given level, which may be a custom level
+func (l *Logger) Logf(level LogLevel, format string, v ...interface{}) {
+	if l.GetLevel() <= level {
+		l.log(level, nil, format, v...)
+	}
+}
+
+// Logln logs a space-separated list of values at the given level, which may be a custom level
+func (l *Logger) Logln(level LogLevel, v ...interface{}) {
+	if l.GetLevel() <= level {
+		l.logln(level, nil, v...)
+	}
+}
+
+// LogKV logs a message with structured key/value fields at the given level.
+// kv may mix Field values with alternating string keys and values.
+func (l *Logger) LogKV(level LogLevel, msg string, kv ...interface{}) {
+	if l.GetLevel() <= level {
+		l.logln(level, fieldsFromKV(kv), msg)
+	}
+}
+
+// LogRecord logs a line produced by an adapter, such as an slog handler, that
+// knows when and where the line was created. t replaces the clock reading and
+// pc, a program counter from runtime.Callers, gives the caller; a zero t uses
+// the clock and a zero pc reports no caller. Stack traces start at the caller
+// of LogRecord.
+func (l *Logger) LogRecord(level LogLevel, t time.Time, pc uintptr, msg string, fields []Field) {
+	if l.GetLevel() > level {
+		return
+	}
+	if l.sampler != nil && !l.sampler.allow(level, msg) {
+		return
+	}
+
+	if t.IsZero() {
+		t = l.clock.Now()
+	}
+	entry := l.newEntryAt(level, msg, fields, t)
+	if l.stackTrace && level >= l.stackLevel {
+		entry.Stack = captureStack(1 + l.callerSkip)
+	}
+	entry.Caller = callerFromPC(pc)
+	l.dispatch(entry, nil)
+}
+
+// SimplePrintLines prints each line guarded by a mutex to every text sink;
+// sinks with another encoder, such as JSON, are skipped.
 // This is useful for printing multiple lines without interleaving log messages
 func (l *Logger) SimplePrintLines(lines []string) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
+	var sb strings.Builder
 	for _, line := range lines {
-		fmt.Fprintln(l.writer, line)
+		sb.WriteString(line)
+		sb.WriteByte('\n')
 	}
+
+	// Queued at ERROR so AsyncDropBelowLevel never discards the block
+	for _, s := range l.sinks {
+		if !isTextEncoder(s.encoder) {
+			continue
+		}
+		l.emit(s, LogLevelError, s.applyColor(sb.String()))
+	}
+}
+
+// isTextEncoder reports whether enc renders plain text lines
+func isTextEncoder(enc Encoder) bool {
+	switch enc.(type) {
+	case TextEncoder, *TextEncoder:
+		return true
+	}
+	return false
 }
 
 // log handles formatted logging
-func (l *Logger) log(level LogLevel, format string, v ...interface{}) {
-	levelStr := level.String()
-	prefix := l.getLogPrefix(levelStr)
-	message := fmt.Sprintf(format, v...)
+func (l *Logger) log(level LogLevel, fields []Field, format string, v ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(level, format) {
+		return
+	}
+	l.output(level, fmt.Sprintf(format, v...), fields, collectErrors(v))
+}
 
-	// Get current time for timestamping
-	now := l.now()
-	timeStr := now.Format("2006/01/02 15:04:05.000000")
+// logln handles unformatted logging with space-separated values
+func (l *Logger) logln(level LogLevel, fields []Field, v ...interface{}) {
+	message, errs := l.formatArgs(v...)
+	if l.sampler != nil && !l.sampler.allow(level, message) {
+		return
+	}
+	l.output(level, message, fields, errs)
+}
 
-	// Format the full log line
-	logLine := fmt.Sprintf("%s %s %s\n", timeStr, prefix, message)
+// output renders a single log line with its fields and errors and writes it to each sink
+func (l *Logger) output(level LogLevel, message string, fields []Field, errs []error) {
+	entry := l.newEntry(level, message, fields)
+	if l.stackTrace && level >= l.stackLevel {
+		entry.Stack = captureStack(callerDepth + l.callerSkip)
+	}
 
-	// Write to the writer
-	l.mu.Lock() // Lock to ensure atomic writes
-	defer l.mu.Unlock()
-	fmt.Fprint(l.writer, logLine)
+	l.mu.RLock()
+	hasHooks := len(l.hooks) > 0
+	l.mu.RUnlock()
+	if l.caller || hasHooks {
+		entry.Caller = captureCaller(callerDepth + l.callerSkip)
+	}
+	l.dispatch(entry, errs)
 }
 
-// logln handles unformatted logging with space-separated values
-func (l *Logger) logln(level LogLevel, v ...interface{}) {
-	levelStr := level.String()
-	prefix := l.getLogPrefix(levelStr)
-	message := l.formatArgs(v...)
+// outputSummary logs a line generated by the logger itself, which has no caller or stack
+func (l *Logger) outputSummary(level LogLevel, message string, fields []Field) {
+	l.dispatch(l.newEntry(level, message, fields), nil)
+}
+
+// dispatch runs the hooks and duplicate detection for an entry and writes it
+func (l *Logger) dispatch(entry *Entry, errs []error) {
+	entry.Errors = append(errs, fieldErrors(entry.Fields)...)
+
+	l.mu.RLock()
+	hooks := l.hooks
+	l.mu.RUnlock()
+	if len(hooks) > 0 && !l.runHooks(hooks, entry) {
+		return
+	}
 
-	// Get current time for timestamping
-	now := l.now()
-	timeStr := now.Format("2006/01/02 15:04:05.000000")
+	if l.dedup != nil && l.dedup.observe(entry) {
+		return
+	}
 
-	// Format the full log line
-	logLine := fmt.Sprintf("%s %s %s\n", timeStr, prefix, message)
+	atomic.AddUint64(l.lines, 1)
 
-	// Write to the writer
-	l.mu.Lock() // Lock to ensure atomic writes
-	defer l.mu.Unlock()
-	fmt.Fprint(l.writer, logLine)
+	// Hooks always see the caller, encoders only when it is enabled
+	if !l.caller {
+		entry.Caller = Caller{}
+	} else if !l.callerFunc {
+		entry.Caller.Function = ""
+	}
+
+	l.writeEntry(entry)
+}
+
+// writeEntry encodes an entry for each sink that accepts its level
+func (l *Logger) writeEntry(entry *Entry) {
+	for _, s := range l.sinks {
+		if s.accepts(entry.Level) {
+			entry.Color = s.ansi
+			l.emit(s, entry.Level, s.applyColor(s.encoder.Encode(entry)))
+		}
+	}
 }
 
-// getLogPrefix builds the log prefix with optional delta time
-func (l *Logger) getLogPrefix(levelStr string) string {
+// emit hands an encoded line to the async queue, or writes it directly
+func (l *Logger) emit(s *sink, level LogLevel, line string) {
+	if l.async != nil {
+		l.async.enqueue(s, level, line)
+		return
+	}
+	l.writeLine(s, line)
+}
+
+// writeLine writes an encoded line to a sink, reporting any error
+func (l *Logger) writeLine(s *sink, line string) {
+	l.reportError(s.write(line))
+}
+
+// newEntry captures the logger state for a single log line
+func (l *Logger) newEntry(level LogLevel, message string, fields []Field) *Entry {
+	return l.newEntryAt(level, message, fields, l.clock.Now())
+}
+
+// newEntryAt captures the logger state for a log line that happened at now
+func (l *Logger) newEntryAt(level LogLevel, message string, fields []Field, now time.Time) *Entry {
 	l.mu.RLock()
-	defer l.mu.RUnlock()
+	prefix := l.prefix
+	l.mu.RUnlock()
+
+	e := &Entry{
+		Level:   level,
+		Prefix:  prefix,
+		Message: message,
+		Fields:  fields,
+	}
+	l.stampEntryAt(e, now)
+	if len(l.fields) > 0 {
+		e.Fields = append(append(make([]Field, 0, len(l.fields)+len(fields)), l.fields...), fields...)
+	}
+	if len(l.tagKeys) > 0 {
+		e.Tag = findTag(l.tagKeys, e.Fields)
+	}
+	return e
+}
+
+// stampEntry sets the timestamp, time format and delta time of e
+func (l *Logger) stampEntry(e *Entry) {
+	l.stampEntryAt(e, l.clock.Now())
+}
 
-	if l.includeDeltaT {
-		dt := time.Since(l.createTime)
-		return fmt.Sprintf("%s %s: %s", levelStr, dt, l.prefix)
+// stampEntryAt stamps e from a single clock reading, so the timestamp and
+// delta time always agree
+func (l *Logger) stampEntryAt(e *Entry, now time.Time) {
+	l.mu.RLock()
+	includeDeltaT := l.includeDeltaT
+	l.mu.RUnlock()
+
+	e.Time = l.timestamp(now)
+	e.TimeFormat = l.timeFormat
+	if includeDeltaT || l.timeFormat == TimeFormatElapsed {
+		e.DeltaTime = now.Sub(l.createTime)
+		e.HasDeltaTime = includeDeltaT
 	}
-	return fmt.Sprintf("%s: %s", levelStr, l.prefix)
 }
 
 func FormatArgIntoString(arg interface{}) (s string) {
@@ -262,9 +629,10 @@ func FormatArgIntoString(arg interface{}) (s string) {
 	return fmt.Sprint(arg)
 }
 
-// formatArgs handles special formatting for nil values
-func (l *Logger) formatArgs(v ...interface{}) string {
+// formatArgs handles special formatting for nil values and collects error arguments
+func (l *Logger) formatArgs(v ...interface{}) (string, []error) {
 	args := make([]string, len(v))
+	var errs []error
 
 	for i, arg := range v {
 		if arg == nil {
@@ -272,14 +640,17 @@ func (l *Logger) formatArgs(v ...interface{}) string {
 			continue
 		}
 
-		val := reflect.ValueOf(arg)
-		if (val.Kind() == reflect.Ptr || val.Kind() == reflect.Slice || val.Kind() == reflect.Map) && val.IsNil() {
-			args[i] = fmt.Sprintf("<nil %s at arg %d>", val.Type(), i)
+		if typedNil(arg) {
+			args[i] = fmt.Sprintf("<nil %s at arg %d>", reflect.TypeOf(arg), i)
 			continue
 		}
 
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+
 		args[i] = FormatArgIntoString(arg)
 	}
 
-	return strings.Join(args, " ")
+	return strings.Join(args, " "), errs
 }
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestLoggerWith tests that derived loggers inherit settings and append fields
+func TestLoggerWith(t *testing.T) {
+	var buf bytes.Buffer
+	parent := NewLogger("TEST", WithLevel(LogLevelInfo), WithZeroTime(), WithWriter(&buf))
+
+	child := parent.With("request", 7).With(String("user", "bob"))
+	child.Debugln("hidden")
+	child.Infoln("handled")
+	child.InfoKV("done", "bytes", 12)
+	parent.Infoln("parent")
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST handled request=7 user=bob\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST done request=7 user=bob bytes=12\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST parent\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerNamed tests dotted sub-prefixes on derived loggers
+func TestLoggerNamed(t *testing.T) {
+	var buf bytes.Buffer
+	parent := NewLogger("plc", WithZeroTime(), WithWriter(&buf))
+
+	parent.Named("divert").Named("robot1").Infoln("ready")
+
+	expected := "0001/01/01 00:00:00.000000 INFO: plc.divert.robot1 ready\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	if prefix := NewLogger("").Named("sub").GetPrefix(); prefix != "sub" {
+		t.Errorf("GetPrefix() = %q, want %q", prefix, "sub")
+	}
+}
+
+// TestLoggerLevelPropagation tests that parent level changes reach children only when enabled
+func TestLoggerLevelPropagation(t *testing.T) {
+	var buf bytes.Buffer
+
+	fixed := NewLogger("TEST", WithLevel(LogLevelInfo), WithWriter(&buf))
+	fixedChild := fixed.Named("child")
+	fixed.SetLevel(LogLevelError)
+	if level := fixedChild.GetLevel(); level != LogLevelInfo {
+		t.Errorf("Child without propagation has level %s, want %s", level, LogLevelInfo)
+	}
+
+	parent := NewLogger("TEST", WithLevel(LogLevelInfo), WithLevelPropagation(true), WithWriter(&buf))
+	child := parent.Named("child")
+	grandchild := child.With("k", "v")
+
+	parent.SetLevel(LogLevelWarn)
+	if level := grandchild.GetLevel(); level != LogLevelWarn {
+		t.Errorf("Grandchild has level %s, want %s", level, LogLevelWarn)
+	}
+
+	child.SetLevel(LogLevelDebug)
+	parent.SetLevel(LogLevelError)
+	lateGrandchild := child.Named("late")
+	if level := child.GetLevel(); level != LogLevelDebug {
+		t.Errorf("Child with explicit level has level %s, want %s", level, LogLevelDebug)
+	}
+	if level := parent.Named("sibling").GetLevel(); level != LogLevelError {
+		t.Errorf("Sibling has level %s, want %s", level, LogLevelError)
+	}
+
+	// Grandchildren follow their direct parent regardless of creation order
+	for name, gc := range map[string]*Logger{"earlier": grandchild, "later": lateGrandchild} {
+		if level := gc.GetLevel(); level != LogLevelDebug {
+			t.Errorf("Grandchild created %s has level %s, want %s", name, level, LogLevelDebug)
+		}
+	}
+	child.SetLevel(LogLevelWarn)
+	if level := grandchild.GetLevel(); level != LogLevelWarn {
+		t.Errorf("Grandchild has level %s after child change, want %s", level, LogLevelWarn)
+	}
+}
@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// LevelEnv is the environment variable read by WithEnvLevel for the global logger
+const LevelEnv = "GOLOGGER_LEVEL"
+
+// levelAliases maps alternative spellings onto levels
+var levelAliases = map[string]LogLevel{
+	"WARNING": LogLevelWarn,
+	"ERR":     LogLevelError,
+}
+
+// ParseLevel converts a case-insensitive level name such as "warn" or "DEBUG",
+// a registered custom level name, or a number into a LogLevel
+func ParseLevel(s string) (LogLevel, error) {
+	if level, ok := LevelByName(s); ok {
+		return level, nil
+	}
+
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if level, ok := levelAliases[name]; ok {
+		return level, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		return LogLevel(n), nil
+	}
+	return 0, fmt.Errorf("logger: unknown level %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler. Unnamed levels are written as numbers.
+func (l LogLevel) MarshalText() ([]byte, error) {
+	name := l.String()
+	if name == "UNKNOWN" {
+		name = strconv.Itoa(int(l))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// Set implements flag.Value, so a LogLevel can be used with flag.Var
+func (l *LogLevel) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
+}
+
+// levelSpec is a parsed "info,db=debug" level specification
+type levelSpec struct {
+	level     LogLevel
+	hasLevel  bool
+	overrides []levelOverride
+}
+
+// levelOverride applies a level to loggers whose prefix or name matches a glob pattern
+type levelOverride struct {
+	pattern string
+	level   LogLevel
+}
+
+// matches reports whether name matches the override's pattern, ignoring case
+func (o levelOverride) matches(name string) bool {
+	ok, err := path.Match(strings.ToLower(o.pattern), strings.ToLower(name))
+	return err == nil && ok
+}
+
+// parseLevelSpec parses a comma separated list of a default level and
+// prefix=level overrides. Invalid entries are reported but do not stop parsing.
+func parseLevelSpec(spec string) (levelSpec, error) {
+	var result levelSpec
+	var bad []string
+
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		prefix, value, isOverride := strings.Cut(item, "=")
+		level, err := ParseLevel(value)
+		if !isOverride {
+			level, err = ParseLevel(prefix)
+		}
+		if err != nil || (isOverride && strings.TrimSpace(prefix) == "") {
+			bad = append(bad, item)
+			continue
+		}
+
+		if isOverride {
+			result.overrides = append(result.overrides, levelOverride{pattern: strings.TrimSpace(prefix), level: level})
+		} else {
+			result.level = level
+			result.hasLevel = true
+		}
+	}
+
+	if len(bad) > 0 {
+		return result, fmt.Errorf("logger: invalid level spec entries %q", bad)
+	}
+	return result, nil
+}
+
+// levelFor returns the level the spec assigns to a logger with the given name
+func (s levelSpec) levelFor(name string) (LogLevel, bool) {
+	if level, ok := s.overrideFor(name); ok {
+		return level, true
+	}
+	return s.level, s.hasLevel
+}
+
+// overrideFor returns the level of the last override matching name
+func (s levelSpec) overrideFor(name string) (LogLevel, bool) {
+	for i := len(s.overrides) - 1; i >= 0; i-- {
+		if s.overrides[i].matches(name) {
+			return s.overrides[i].level, true
+		}
+	}
+	return 0, false
+}
+
+// WithEnvLevel sets the level from the environment variable name, if set, using
+// the form "info,db=debug,plc.*=trace": a default level plus overrides for
+// loggers whose registry name matches the glob pattern. Later overrides win,
+// and overrides also apply to Named children. Invalid entries are ignored.
+func WithEnvLevel(name string) LoggerOption {
+	return func(l *Logger) {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return
+		}
+
+		spec, _ := parseLevelSpec(value)
+		l.envSpec = &spec
+	}
+}
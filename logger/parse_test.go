@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// TestParseLevel tests names, aliases, numbers and errors
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected LogLevel
+		err      bool
+	}{
+		{"warn", LogLevelWarn, false},
+		{"DEBUG", LogLevelDebug, false},
+		{" Info ", LogLevelInfo, false},
+		{"warning", LogLevelWarn, false},
+		{"trace", LogLevelTrace, false},
+		{"fatal", LogLevelFatal, false},
+		{"2", LogLevelWarn, false},
+		{"-1", LogLevelTrace, false},
+		{"verbose", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			level, err := ParseLevel(tc.input)
+			if (err != nil) != tc.err {
+				t.Fatalf("ParseLevel(%q) error = %v, want error %v", tc.input, err, tc.err)
+			}
+			if !tc.err && level != tc.expected {
+				t.Errorf("ParseLevel(%q) = %s, want %s", tc.input, level, tc.expected)
+			}
+		})
+	}
+}
+
+// TestLevelTextAndFlag tests the TextMarshaler and flag.Value implementations
+func TestLevelTextAndFlag(t *testing.T) {
+	data, err := json.Marshal(map[string]LogLevel{"a": LogLevelWarn, "b": LogLevel(42)})
+	if err != nil || string(data) != `{"a":"WARN","b":"42"}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+
+	var decoded map[string]LogLevel
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded["a"] != LogLevelWarn || decoded["b"] != LogLevel(42) {
+		t.Errorf("json.Unmarshal = %v, %v", decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`"loud"`), new(LogLevel)); err == nil {
+		t.Error("Expected error unmarshaling unknown level")
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	level := LogLevelInfo
+	fs.Var(&level, "level", "log level")
+	if err := fs.Parse([]string{"-level", "error"}); err != nil || level != LogLevelError {
+		t.Errorf("flag parse gave %s, %v", level, err)
+	}
+}
+
+// TestWithEnvLevel tests default levels and per-prefix overrides from the environment
+func TestWithEnvLevel(t *testing.T) {
+	t.Setenv("TEST_GOLOGGER_LEVEL", "warn, db=debug, bogus, =info")
+
+	if level := NewLogger("api", WithEnvLevel("TEST_GOLOGGER_LEVEL")).GetLevel(); level != LogLevelWarn {
+		t.Errorf("api level %s, want WARN", level)
+	}
+	db := NewLogger(coloransi.Foreground(coloransi.Red, "DB"), WithLevel(LogLevelError), WithEnvLevel("TEST_GOLOGGER_LEVEL"))
+	if level := db.GetLevel(); level != LogLevelDebug {
+		t.Errorf("db level %s, want DEBUG", level)
+	}
+	if level := NewLogger("api", WithLevel(LogLevelError), WithEnvLevel("TEST_GOLOGGER_UNSET")).GetLevel(); level != LogLevelError {
+		t.Errorf("Unset variable changed level to %s", level)
+	}
+
+	t.Setenv("TEST_GOLOGGER_LEVEL", "info,plc.*=trace,plc.robot=error")
+	if level := NewLogger("plc.divert", WithEnvLevel("TEST_GOLOGGER_LEVEL")).GetLevel(); level != LogLevelTrace {
+		t.Errorf("plc.divert level %s, want TRACE", level)
+	}
+	if level := NewLogger("plc.robot", WithEnvLevel("TEST_GOLOGGER_LEVEL")).GetLevel(); level != LogLevelError {
+		t.Errorf("plc.robot level %s, want ERROR", level)
+	}
+
+	plc := NewLogger("P", WithName("plc"), WithLevel(LogLevelWarn), WithEnvLevel("TEST_GOLOGGER_LEVEL"))
+	if level := plc.GetLevel(); level != LogLevelInfo {
+		t.Errorf("plc level %s, want INFO", level)
+	}
+	if level := plc.Named("robot").GetLevel(); level != LogLevelError {
+		t.Errorf("plc.robot child level %s, want ERROR", level)
+	}
+	plc.SetLevel(LogLevelWarn)
+	if level := plc.Named("other").Named("x").GetLevel(); level != LogLevelTrace {
+		t.Errorf("plc.other.x child level %s, want TRACE", level)
+	}
+	misc := NewLogger("misc", WithEnvLevel("TEST_GOLOGGER_LEVEL"))
+	misc.SetLevel(LogLevelWarn)
+	if level := misc.Named("sub").GetLevel(); level != LogLevelWarn {
+		t.Errorf("misc.sub child level %s, want the inherited WARN", level)
+	}
+
+	if _, err := parseLevelSpec("info,db=loud"); err == nil {
+		t.Error("Expected error for invalid spec entries")
+	}
+}
@@ -0,0 +1,285 @@
+package logger
+
+import (
+	"container/list"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// rateLimitKeys bounds the keys tracked by the Once, EveryN and Every helpers.
+// When it is reached a key is evicted to make room, see rateLimits.evict, so a
+// Once key is forgotten, and may log again, only if every tracked key is a Once key.
+const rateLimitKeys = 4096
+
+// rateLimits holds the state of the Once, EveryN and Every helpers
+type rateLimits struct {
+	mu     sync.Mutex
+	states map[limitKey]*limitState
+	recent *list.List // EveryN and Every keys, most recently used first
+	onces  *list.List // Once keys, most recently used first
+	now    time.Time  // latest time passed to every
+	swept  time.Time  // now at the last sweep of expired windows
+}
+
+// limitKey is either an explicit key or, when that is empty, the call site
+type limitKey struct {
+	name string
+	file string
+	line int
+}
+
+type limitState struct {
+	count  uint64
+	last   time.Time
+	window time.Duration // interval of the latest Every call
+	once   bool          // used by a Once helper, kept in onces
+	elem   *list.Element
+}
+
+// state returns the state for k, creating it if needed. The caller must hold r.mu.
+func (r *rateLimits) state(k limitKey) *limitState {
+	if st, ok := r.states[k]; ok {
+		r.order(st).MoveToFront(st.elem)
+		return st
+	}
+
+	if r.states == nil {
+		r.states = make(map[limitKey]*limitState)
+		r.recent = list.New()
+		r.onces = list.New()
+	}
+	if len(r.states) >= rateLimitKeys {
+		r.evict()
+	}
+	st := &limitState{elem: r.recent.PushFront(k)}
+	r.states[k] = st
+	return st
+}
+
+// order returns the list holding st
+func (r *rateLimits) order(st *limitState) *list.List {
+	if st.once {
+		return r.onces
+	}
+	return r.recent
+}
+
+// evict makes room for a new key. It drops the Every keys whose window has
+// expired, which would log on their next call anyway; if there are none, the
+// least recently used key that is not a Once key; and only if every key is a
+// Once key, the oldest of those. The caller must hold r.mu.
+func (r *rateLimits) evict() {
+	if r.now.After(r.swept) {
+		r.swept = r.now
+		freed := false
+		for k, st := range r.states {
+			if !st.once && st.window > 0 && r.now.Sub(st.last) >= st.window {
+				r.remove(k, st)
+				freed = true
+			}
+		}
+		if freed {
+			return
+		}
+	}
+
+	order := r.recent
+	if order.Len() == 0 {
+		order = r.onces
+	}
+	k := order.Back().Value.(limitKey)
+	r.remove(k, r.states[k])
+}
+
+// remove forgets k. The caller must hold r.mu.
+func (r *rateLimits) remove(k limitKey, st *limitState) {
+	r.order(st).Remove(st.elem)
+	delete(r.states, k)
+}
+
+// once reports whether k is seen for the first time
+func (r *rateLimits) once(k limitKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st := r.state(k)
+	if !st.once {
+		r.recent.Remove(st.elem)
+		st.once = true
+		st.elem = r.onces.PushFront(k)
+	}
+	st.count++
+	return st.count == 1
+}
+
+// everyN reports whether this is the 1st, n+1th, 2n+1th... occurrence of k
+func (r *rateLimits) everyN(k limitKey, n int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st := r.state(k)
+	st.count++
+	return n <= 1 || (st.count-1)%uint64(n) == 0
+}
+
+// every reports whether at least d has passed since k was last allowed
+func (r *rateLimits) every(k limitKey, d time.Duration, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.After(r.now) {
+		r.now = now
+	}
+	st := r.state(k)
+	st.window = d
+	if !st.last.IsZero() && now.Sub(st.last) < d {
+		return false
+	}
+	st.last = now
+	return true
+}
+
+// limitKey returns the key for a helper call, using the call site of the
+// public method when key is empty. It must be called directly from that method.
+func (l *Logger) limitKey(key string) limitKey {
+	if key != "" {
+		return limitKey{name: key}
+	}
+	// file:line rather than the PC, which differs between inlined copies of a caller
+	_, file, line, _ := runtime.Caller(2 + l.callerSkip)
+	return limitKey{file: file, line: line}
+}
+
+// ResetRateLimits forgets the state of the Once, EveryN and Every helpers for
+// this logger and every logger derived from the same root, mainly for tests
+func (l *Logger) ResetRateLimits() {
+	l.limits.mu.Lock()
+	l.limits.states = nil
+	l.limits.recent = nil
+	l.limits.onces = nil
+	l.limits.mu.Unlock()
+}
+
+// TraceOnce logs a formatted message at TRACE level only the first time key (or, if empty, the call site) is seen
+func (l *Logger) TraceOnce(key string, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelTrace && l.limits.once(l.limitKey(key)) {
+		l.log(LogLevelTrace, nil, format, v...)
+	}
+}
+
+// DebugOnce logs a formatted message at DEBUG level only the first time key (or, if empty, the call site) is seen
+func (l *Logger) DebugOnce(key string, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelDebug && l.limits.once(l.limitKey(key)) {
+		l.log(LogLevelDebug, nil, format, v...)
+	}
+}
+
+// InfoOnce logs a formatted message at INFO level only the first time key (or, if empty, the call site) is seen
+func (l *Logger) InfoOnce(key string, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelInfo && l.limits.once(l.limitKey(key)) {
+		l.log(LogLevelInfo, nil, format, v...)
+	}
+}
+
+// WarnOnce logs a formatted message at WARN level only the first time key (or, if empty, the call site) is seen
+func (l *Logger) WarnOnce(key string, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelWarn && l.limits.once(l.limitKey(key)) {
+		l.log(LogLevelWarn, nil, format, v...)
+	}
+}
+
+// ErrorOnce logs a formatted message at ERROR level only the first time key (or, if empty, the call site) is seen
+func (l *Logger) ErrorOnce(key string, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelError && l.limits.once(l.limitKey(key)) {
+		l.log(LogLevelError, nil, format, v...)
+	}
+}
+
+// LogOnce logs a formatted message at the given level only the first time key (or, if empty, the call site) is seen
+func (l *Logger) LogOnce(level LogLevel, key string, format string, v ...interface{}) {
+	if l.GetLevel() <= level && l.limits.once(l.limitKey(key)) {
+		l.log(level, nil, format, v...)
+	}
+}
+
+// TraceEveryN logs a formatted message at TRACE level on the first and then every n-th call for key (or, if empty, the call site)
+func (l *Logger) TraceEveryN(key string, n int, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelTrace && l.limits.everyN(l.limitKey(key), n) {
+		l.log(LogLevelTrace, nil, format, v...)
+	}
+}
+
+// DebugEveryN logs a formatted message at DEBUG level on the first and then every n-th call for key (or, if empty, the call site)
+func (l *Logger) DebugEveryN(key string, n int, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelDebug && l.limits.everyN(l.limitKey(key), n) {
+		l.log(LogLevelDebug, nil, format, v...)
+	}
+}
+
+// InfoEveryN logs a formatted message at INFO level on the first and then every n-th call for key (or, if empty, the call site)
+func (l *Logger) InfoEveryN(key string, n int, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelInfo && l.limits.everyN(l.limitKey(key), n) {
+		l.log(LogLevelInfo, nil, format, v...)
+	}
+}
+
+// WarnEveryN logs a formatted message at WARN level on the first and then every n-th call for key (or, if empty, the call site)
+func (l *Logger) WarnEveryN(key string, n int, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelWarn && l.limits.everyN(l.limitKey(key), n) {
+		l.log(LogLevelWarn, nil, format, v...)
+	}
+}
+
+// ErrorEveryN logs a formatted message at ERROR level on the first and then every n-th call for key (or, if empty, the call site)
+func (l *Logger) ErrorEveryN(key string, n int, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelError && l.limits.everyN(l.limitKey(key), n) {
+		l.log(LogLevelError, nil, format, v...)
+	}
+}
+
+// LogEveryN logs a formatted message at the given level on the first and then every n-th call for key (or, if empty, the call site)
+func (l *Logger) LogEveryN(level LogLevel, key string, n int, format string, v ...interface{}) {
+	if l.GetLevel() <= level && l.limits.everyN(l.limitKey(key), n) {
+		l.log(level, nil, format, v...)
+	}
+}
+
+// TraceEvery logs a formatted message at TRACE level at most once every d for key (or, if empty, the call site)
+func (l *Logger) TraceEvery(key string, d time.Duration, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelTrace && l.limits.every(l.limitKey(key), d, l.clock.Now()) {
+		l.log(LogLevelTrace, nil, format, v...)
+	}
+}
+
+// DebugEvery logs a formatted message at DEBUG level at most once every d for key (or, if empty, the call site)
+func (l *Logger) DebugEvery(key string, d time.Duration, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelDebug && l.limits.every(l.limitKey(key), d, l.clock.Now()) {
+		l.log(LogLevelDebug, nil, format, v...)
+	}
+}
+
+// InfoEvery logs a formatted message at INFO level at most once every d for key (or, if empty, the call site)
+func (l *Logger) InfoEvery(key string, d time.Duration, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelInfo && l.limits.every(l.limitKey(key), d, l.clock.Now()) {
+		l.log(LogLevelInfo, nil, format, v...)
+	}
+}
+
+// WarnEvery logs a formatted message at WARN level at most once every d for key (or, if empty, the call site)
+func (l *Logger) WarnEvery(key string, d time.Duration, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelWarn && l.limits.every(l.limitKey(key), d, l.clock.Now()) {
+		l.log(LogLevelWarn, nil, format, v...)
+	}
+}
+
+// ErrorEvery logs a formatted message at ERROR level at most once every d for key (or, if empty, the call site)
+func (l *Logger) ErrorEvery(key string, d time.Duration, format string, v ...interface{}) {
+	if l.GetLevel() <= LogLevelError && l.limits.every(l.limitKey(key), d, l.clock.Now()) {
+		l.log(LogLevelError, nil, format, v...)
+	}
+}
+
+// LogEvery logs a formatted message at the given level at most once every d for key (or, if empty, the call site)
+func (l *Logger) LogEvery(level LogLevel, key string, d time.Duration, format string, v ...interface{}) {
+	if l.GetLevel() <= level && l.limits.every(l.limitKey(key), d, l.clock.Now()) {
+		l.log(level, nil, format, v...)
+	}
+}
@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLoggerOnce tests per-key and per-call-site Once helpers
+func TestLoggerOnce(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithLevel(LogLevelInfo), WithZeroTime(), WithWriter(&buf))
+
+	for i := 0; i < 3; i++ {
+		logger.InfoOnce("", "site a %d", i)
+		logger.InfoOnce("", "site b %d", i)
+		logger.Named("child").WarnOnce("shared", "keyed %d", i)
+		logger.DebugOnce("hidden", "debug %d", i)
+	}
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST site a 0\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST site b 0\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST.child keyed 0\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	// Disabled levels do not consume the key
+	logger.SetLevel(LogLevelDebug)
+	buf.Reset()
+	logger.DebugOnce("hidden", "debug %d", 3)
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 DEBUG: TEST debug 3\n" {
+		t.Errorf("Unexpected output after enabling DEBUG: %q", output)
+	}
+
+	logger.ResetRateLimits()
+	buf.Reset()
+	logger.WarnOnce("shared", "again")
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 WARN: TEST again\n" {
+		t.Errorf("Expected key to log again after reset, got: %q", output)
+	}
+}
+
+// TestLoggerEveryN tests that every n-th call is logged starting with the first
+func TestLoggerEveryN(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+	for i := 1; i <= 7; i++ {
+		logger.WarnEveryN("poll", 3, "poll %d", i)
+	}
+
+	expected := "0001/01/01 00:00:00.000000 WARN: TEST poll 1\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST poll 4\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST poll 7\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerEvery tests duration based limiting
+func TestLoggerEvery(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+	tick := func() {
+		logger.DebugEvery("", 30*time.Millisecond, "tick")
+	}
+	tick()
+	tick()
+	logger.DebugEvery("", 30*time.Millisecond, "other site")
+	if count := strings.Count(buf.String(), "DEBUG: TEST tick\n"); count != 1 {
+		t.Errorf("Expected 1 tick within the interval, got %d: %q", count, buf.String())
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	tick()
+	logger.LogEvery(LogLevelInfo, "k", time.Hour, "first")
+	logger.LogEvery(LogLevelInfo, "k", time.Hour, "second")
+
+	if count := strings.Count(buf.String(), "DEBUG: TEST tick\n"); count != 2 {
+		t.Errorf("Expected 2 ticks after the interval, got %d: %q", count, buf.String())
+	}
+	if !strings.Contains(buf.String(), "DEBUG: TEST other site\n") {
+		t.Errorf("Expected other call site to log, got: %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "second") {
+		t.Errorf("Expected keyed line once per hour, got: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "INFO: TEST first\n") {
+		t.Errorf("Expected keyed line, got: %q", buf.String())
+	}
+}
+
+// TestLoggerRateLimitCaller tests that the helpers report the user's call site
+func TestLoggerRateLimitCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithCaller(0))
+
+	logger.InfoOnce("", "once")
+	expected := fmt.Sprintf("ratelimit_test.go:%d once", line()-1)
+	if output := buf.String(); !strings.Contains(output, expected) {
+		t.Errorf("Expected %q in output, got: %q", expected, output)
+	}
+}
+
+// TestLoggerRateLimitBound tests that the key map does not grow without bound
+func TestLoggerRateLimitBound(t *testing.T) {
+	logger := NewLogger("TEST", WithWriter(&bytes.Buffer{}))
+
+	for i := 0; i < rateLimitKeys*2; i++ {
+		logger.InfoOnce(fmt.Sprint(i), "key %d", i)
+	}
+	if n := len(logger.limits.states); n > rateLimitKeys {
+		t.Errorf("Tracked %d keys, want at most %d", n, rateLimitKeys)
+	}
+}
+
+// TestLoggerRateLimitEviction tests that Once keys survive reaching the bound
+func TestLoggerRateLimitEviction(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &testClock{}
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithClock(clock))
+
+	logger.InfoOnce("start", "started")
+	for i := 0; i < rateLimitKeys; i++ {
+		logger.InfoEvery(fmt.Sprint("every", i), time.Second, "every %d", i)
+	}
+	clock.Advance(time.Minute)
+	logger.InfoEvery("late", time.Second, "late")
+	if n := len(logger.limits.states); n != 2 {
+		t.Errorf("Expected expired windows to be evicted first, tracking %d keys", n)
+	}
+	for i := 0; i < rateLimitKeys; i++ {
+		logger.InfoEveryN(fmt.Sprint("n", i), 10, "n %d", i)
+	}
+
+	buf.Reset()
+	logger.InfoOnce("start", "started again")
+	if output := buf.String(); output != "" {
+		t.Errorf("Expected Once key to stay silent after eviction, got: %q", output)
+	}
+	if n := len(logger.limits.states); n > rateLimitKeys {
+		t.Errorf("Tracked %d keys, want at most %d", n, rateLimitKeys)
+	}
+}
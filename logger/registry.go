@@ -0,0 +1,210 @@
+package logger
+
+import (
+	"path"
+	"sort"
+	"sync"
+)
+
+// registry tracks named loggers and the level overrides applied to them
+var registry = struct {
+	mu        sync.Mutex
+	loggers   map[string][]*Logger
+	overrides []levelOverride
+}{
+	loggers: make(map[string][]*Logger),
+}
+
+// WithName sets the name the logger is registered under in the global registry,
+// so its level can be changed with SetLevelFor. Without it the name is the
+// prefix without colors. Overrides matching the name are applied at creation.
+// Several loggers may share a name.
+func WithName(name string) LoggerOption {
+	return func(l *Logger) {
+		l.name = name
+	}
+}
+
+// Name returns the registry name of the logger, shared by children from With.
+// Loggers with an empty name are not registered.
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// register applies the environment level spec to a new logger, then adds it
+// to the registry
+func (l *Logger) register() {
+	if l.envSpec != nil {
+		if level, ok := l.envSpec.levelFor(l.name); ok {
+			l.level = level
+		}
+	}
+	l.addToRegistry()
+}
+
+// registerNamed applies environment overrides matching a Named child's name,
+// keeping the inherited level otherwise, then adds it to the registry
+func (l *Logger) registerNamed() {
+	if l.envSpec != nil {
+		if level, ok := l.envSpec.overrideFor(l.name); ok {
+			l.level = level
+			l.levelParent = nil
+		}
+	}
+	l.addToRegistry()
+}
+
+// addToRegistry adds a named logger and applies any matching overrides
+func (l *Logger) addToRegistry() {
+	l.baseLevel = l.level
+	if l.name == "" {
+		return
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.loggers[l.name] = append(registry.loggers[l.name], l)
+	if level, ok := matchOverride(l.name); ok {
+		l.level = level
+		l.levelParent = nil
+	}
+}
+
+// DefaultLevel returns the level the logger would get if it were created now:
+// the latest override matching its name, else the level it was configured with
+func (l *Logger) DefaultLevel() LogLevel {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if level, ok := matchOverride(l.name); ok && l.name != "" {
+		return level
+	}
+	return l.baseLevel
+}
+
+// matchOverride returns the level of the latest override matching name.
+// The caller must hold registry.mu.
+func matchOverride(name string) (LogLevel, bool) {
+	for i := len(registry.overrides) - 1; i >= 0; i-- {
+		if registry.overrides[i].matches(name) {
+			return registry.overrides[i].level, true
+		}
+	}
+	return 0, false
+}
+
+// Unregister removes the logger from the global registry
+func (l *Logger) Unregister() {
+	if l.name == "" {
+		return
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	loggers := registry.loggers[l.name]
+	for i, other := range loggers {
+		if other == l {
+			loggers = append(loggers[:i], loggers[i+1:]...)
+			break
+		}
+	}
+	if len(loggers) == 0 {
+		delete(registry.loggers, l.name)
+	} else {
+		registry.loggers[l.name] = loggers
+	}
+}
+
+// SetLevelFor sets level on every registered logger whose name matches the glob
+// pattern (e.g. "plc.*") and remembers it for loggers registered later.
+// It returns the number of loggers updated.
+func SetLevelFor(pattern string, level LogLevel) (int, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return 0, err
+	}
+
+	registry.mu.Lock()
+	override := levelOverride{pattern: pattern, level: level}
+	registry.overrides = append(removeOverride(registry.overrides, pattern), override)
+	var matched []*Logger
+	for name, loggers := range registry.loggers {
+		if override.matches(name) {
+			matched = append(matched, loggers...)
+		}
+	}
+	registry.mu.Unlock()
+
+	for _, l := range matched {
+		l.SetLevel(level)
+	}
+	return len(matched), nil
+}
+
+// ClearLevelFor forgets the override for pattern. Levels already applied are kept.
+func ClearLevelFor(pattern string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.overrides = removeOverride(registry.overrides, pattern)
+}
+
+// LevelFor returns the override remembered for exactly pattern, if any
+func LevelFor(pattern string) (LogLevel, bool) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for _, o := range registry.overrides {
+		if o.pattern == pattern {
+			return o.level, true
+		}
+	}
+	return 0, false
+}
+
+// RegisteredLoggers returns every registered logger ordered by name
+func RegisteredLoggers() []*Logger {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	names := make([]string, 0, len(registry.loggers))
+	for name := range registry.loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []*Logger
+	for _, name := range names {
+		result = append(result, registry.loggers[name]...)
+	}
+	return result
+}
+
+// LoggersMatching returns the registered loggers whose name matches the glob pattern
+func LoggersMatching(pattern string) []*Logger {
+	override := levelOverride{pattern: pattern}
+
+	var result []*Logger
+	for _, l := range RegisteredLoggers() {
+		if override.matches(l.name) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// LookupLoggers returns the registered loggers with exactly the given name
+func LookupLoggers(name string) []*Logger {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return append([]*Logger(nil), registry.loggers[name]...)
+}
+
+func removeOverride(overrides []levelOverride, pattern string) []levelOverride {
+	result := overrides[:0]
+	for _, o := range overrides {
+		if o.pattern != pattern {
+			result = append(result, o)
+		}
+	}
+	return result
+}
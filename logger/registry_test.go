@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// resetRegistry gives the test an empty global registry, restoring the
+// previous loggers and overrides when it ends
+func resetRegistry(t *testing.T) {
+	registry.mu.Lock()
+	loggers, overrides := registry.loggers, registry.overrides
+	registry.loggers, registry.overrides = make(map[string][]*Logger), nil
+	registry.mu.Unlock()
+
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		registry.loggers, registry.overrides = loggers, overrides
+	})
+}
+
+// TestRegistrySetLevelFor tests live updates by glob pattern and overrides for new loggers
+func TestRegistrySetLevelFor(t *testing.T) {
+	resetRegistry(t)
+
+	divert := NewLogger("divert", WithLevel(LogLevelInfo), WithName("plc.divert"))
+	scanner := NewLogger("scanner", WithLevel(LogLevelInfo), WithName("plc.scanner"))
+	db := NewLogger("db", WithLevel(LogLevelInfo), WithName("db"))
+
+	n, err := SetLevelFor("plc.*", LogLevelDebug)
+	if err != nil || n != 2 {
+		t.Fatalf("SetLevelFor returned %d, %v", n, err)
+	}
+	if divert.GetLevel() != LogLevelDebug || scanner.GetLevel() != LogLevelDebug {
+		t.Errorf("plc loggers not updated: %s %s", divert.GetLevel(), scanner.GetLevel())
+	}
+	if db.GetLevel() != LogLevelInfo {
+		t.Errorf("db logger changed to %s", db.GetLevel())
+	}
+
+	robot := NewLogger("robot", WithLevel(LogLevelError), WithName("plc.robot"))
+	if robot.GetLevel() != LogLevelDebug {
+		t.Errorf("New logger did not pick up override, level %s", robot.GetLevel())
+	}
+
+	if _, err := SetLevelFor("PLC.SCANNER", LogLevelWarn); err != nil {
+		t.Fatal(err)
+	}
+	if scanner.GetLevel() != LogLevelWarn || divert.GetLevel() != LogLevelDebug {
+		t.Errorf("Exact override gave %s and %s", scanner.GetLevel(), divert.GetLevel())
+	}
+
+	if level, ok := LevelFor("plc.*"); !ok || level != LogLevelDebug {
+		t.Errorf("LevelFor(plc.*) = %s, %v", level, ok)
+	}
+
+	ClearLevelFor("plc.*")
+	ClearLevelFor("PLC.SCANNER")
+	if _, ok := LevelFor("plc.*"); ok {
+		t.Error("Expected no override after ClearLevelFor")
+	}
+	if level := NewLogger("late", WithLevel(LogLevelError), WithName("plc.robot")).GetLevel(); level != LogLevelError {
+		t.Errorf("Cleared override still applied, level %s", level)
+	}
+
+	if _, err := SetLevelFor("[", LogLevelDebug); err == nil {
+		t.Error("Expected error for malformed pattern")
+	}
+}
+
+// TestRegistryLookup tests listing and unregistering loggers
+func TestRegistryLookup(t *testing.T) {
+	var global bool
+	for _, l := range LookupLoggers("global") {
+		global = global || l == Log
+	}
+	if Log.Name() != "global" || !global {
+		t.Error("Expected the global logger to be registered as global")
+	}
+
+	resetRegistry(t)
+
+	b := NewLogger("b", WithName("b.test"))
+	a1 := NewLogger("a", WithName("a.test"))
+	a2 := NewLogger("a", WithName("a.test"))
+	NewLogger("unnamed")
+
+	var names []string
+	for _, l := range RegisteredLoggers() {
+		if l == a1 || l == a2 || l == b {
+			names = append(names, l.Name())
+		}
+	}
+	if len(names) != 3 || names[0] != "a.test" || names[2] != "b.test" {
+		t.Errorf("Unexpected registered loggers %v", names)
+	}
+
+	a1.Unregister()
+	if found := LookupLoggers("a.test"); len(found) != 1 || found[0] != a2 {
+		t.Errorf("LookupLoggers after Unregister = %v", found)
+	}
+	a2.Unregister()
+	if found := LookupLoggers("a.test"); len(found) != 0 {
+		t.Errorf("Expected no loggers, got %v", found)
+	}
+
+	if found := LookupLoggers("unnamed"); len(found) != 1 || found[0].Name() != "unnamed" {
+		t.Errorf("Expected the prefix as default name, got %v", found)
+	}
+}
+
+// TestRegistryNamed tests that Named children are registered under dotted names
+func TestRegistryNamed(t *testing.T) {
+	resetRegistry(t)
+
+	plc := NewLogger(coloransi.Foreground(coloransi.Red, "PLC"), WithLevel(LogLevelInfo))
+	robot := plc.Named("divert").Named("robot1")
+	fields := robot.With("id", 1)
+	if plc.Name() != "PLC" || robot.Name() != "PLC.divert.robot1" || fields.Name() != robot.Name() {
+		t.Fatalf("Unexpected names %q %q %q", plc.Name(), robot.Name(), fields.Name())
+	}
+
+	n, err := SetLevelFor("plc.divert.*", LogLevelDebug)
+	if err != nil || n != 1 {
+		t.Fatalf("SetLevelFor returned %d, %v", n, err)
+	}
+	if robot.GetLevel() != LogLevelDebug || plc.GetLevel() != LogLevelInfo {
+		t.Errorf("Override gave %s and %s", robot.GetLevel(), plc.GetLevel())
+	}
+	if level := plc.Named("divert").Named("robot2").GetLevel(); level != LogLevelDebug {
+		t.Errorf("New Named child did not pick up override, level %s", level)
+	}
+}
@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	samplerShards = 16
+	// samplerShardKeys bounds the keys tracked per shard; lines with untracked keys are always logged
+	samplerShardKeys = 1024
+)
+
+// sampler limits repetitive lines: within each interval the first lines of a
+// key are logged, then only every thereafter-th one
+type sampler struct {
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	summarize  func(level LogLevel, key string, suppressed uint64)
+	now        func() time.Time
+	shards     [samplerShards]samplerShard
+}
+
+type samplerShard struct {
+	mu     sync.Mutex
+	counts map[samplerKey]*sampleCount
+}
+
+// samplerKey identifies similar lines
+type samplerKey struct {
+	level LogLevel
+	text  string
+}
+
+// sampleCount tracks one key within the current window
+type sampleCount struct {
+	windowEnd  time.Time
+	seen       uint64
+	suppressed uint64
+	timer      *time.Timer
+}
+
+// WithSampling logs the first lines of each level and format string (or
+// message, for the ln and KV methods) within every interval, then only every
+// thereafter-th one; zero drops the rest. When a window with suppressed lines
+// closes, a "suppressed N similar messages" line is logged.
+func WithSampling(interval time.Duration, first, thereafter int) LoggerOption {
+	return func(l *Logger) {
+		s := &sampler{
+			interval:   interval,
+			first:      uint64(first),
+			thereafter: uint64(thereafter),
+			summarize:  l.logSuppressed,
+			now:        func() time.Time { return l.clock.Now() },
+		}
+		for i := range s.shards {
+			s.shards[i].counts = make(map[samplerKey]*sampleCount)
+		}
+		l.sampler = s
+	}
+}
+
+// logSuppressed writes the summary line for a closed sampling window
+func (l *Logger) logSuppressed(level LogLevel, key string, suppressed uint64) {
+	l.outputSummary(level, fmt.Sprintf("suppressed %d similar messages", suppressed), []Field{String("sample", key)})
+}
+
+// allow reports whether a line with the given key should be logged
+func (s *sampler) allow(level LogLevel, text string) bool {
+	key := samplerKey{level: level, text: text}
+	shard := &s.shards[fnv32(text)%samplerShards]
+	now := s.now()
+
+	shard.mu.Lock()
+	c, ok := shard.counts[key]
+	var closed uint64
+	if ok && !now.Before(c.windowEnd) {
+		// The window is over, its summary is due now unless the timer already ran
+		closed = c.suppressed
+		if c.timer != nil {
+			c.timer.Stop()
+		}
+		delete(shard.counts, key)
+		ok = false
+	}
+	if !ok {
+		if len(shard.counts) >= samplerShardKeys {
+			shard.sweep(now)
+		}
+		if len(shard.counts) >= samplerShardKeys {
+			shard.mu.Unlock()
+			s.flushClosed(level, text, closed)
+			return true
+		}
+		c = &sampleCount{windowEnd: now.Add(s.interval)}
+		shard.counts[key] = c
+	}
+
+	c.seen++
+	allowed := c.seen <= s.first || (s.thereafter > 0 && (c.seen-s.first)%s.thereafter == 0)
+	if !allowed {
+		c.suppressed++
+		if c.timer == nil {
+			c.timer = time.AfterFunc(c.windowEnd.Sub(now), func() { s.closeWindow(key, c) })
+		}
+	}
+	shard.mu.Unlock()
+
+	s.flushClosed(level, text, closed)
+	return allowed
+}
+
+// closeWindow ends the window of c when its timer fires
+func (s *sampler) closeWindow(key samplerKey, c *sampleCount) {
+	shard := &s.shards[fnv32(key.text)%samplerShards]
+
+	shard.mu.Lock()
+	if shard.counts[key] != c {
+		// Already closed by a later line
+		shard.mu.Unlock()
+		return
+	}
+	delete(shard.counts, key)
+	suppressed := c.suppressed
+	shard.mu.Unlock()
+
+	s.flushClosed(key.level, key.text, suppressed)
+}
+
+// flushClosed emits the summary for a closed window that suppressed lines
+func (s *sampler) flushClosed(level LogLevel, text string, suppressed uint64) {
+	if suppressed > 0 {
+		s.summarize(level, text, suppressed)
+	}
+}
+
+// sweep drops finished windows without suppressed lines to make room for new keys
+func (sh *samplerShard) sweep(now time.Time) {
+	for key, c := range sh.counts {
+		if c.suppressed == 0 && !now.Before(c.windowEnd) {
+			delete(sh.counts, key)
+		}
+	}
+}
+
+// fnv32 is the 32-bit FNV-1a hash of s
+func fnv32(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a bytes.Buffer safe for writes from background goroutines
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestLoggerSampling tests first/thereafter sampling and the summary line
+func TestLoggerSampling(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithSampling(50*time.Millisecond, 2, 3))
+
+	for i := 1; i <= 10; i++ {
+		logger.Warn("tick %d", i)
+	}
+	logger.Infoln("other key")
+
+	expected := "0001/01/01 00:00:00.000000 WARN: TEST tick 1\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST tick 2\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST tick 5\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST tick 8\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST other key\n"
+	if output := buf.String(); output != expected {
+		t.Fatalf("Expected output %q, got: %q", expected, output)
+	}
+
+	summary := "0001/01/01 00:00:00.000000 WARN: TEST suppressed 6 similar messages sample=\"tick %d\"\n"
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.HasSuffix(buf.String(), summary) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Summary line not written, got: %q", buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// A new window starts after the summary
+	logger.Warn("tick %d", 11)
+	if output := buf.String(); !strings.HasSuffix(output, "WARN: TEST tick 11\n") {
+		t.Errorf("Expected new window to log, got: %q", output)
+	}
+}
+
+// TestLoggerSamplingLazySummary tests that a late line closes the previous window
+func TestLoggerSamplingLazySummary(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithSampling(time.Hour, 1, 0))
+	s := logger.sampler
+
+	logger.Errorln("flood")
+	logger.Errorln("flood")
+	logger.Errorln("flood")
+
+	// Expire the window without waiting for its timer
+	shard := &s.shards[fnv32("flood")%samplerShards]
+	shard.mu.Lock()
+	shard.counts[samplerKey{LogLevelError, "flood"}].windowEnd = time.Now().Add(-time.Second)
+	shard.mu.Unlock()
+
+	logger.Errorln("flood")
+	expected := "0001/01/01 00:00:00.000000 ERROR: TEST flood\n" +
+		"0001/01/01 00:00:00.000000 ERROR: TEST suppressed 2 similar messages sample=flood\n" +
+		"0001/01/01 00:00:00.000000 ERROR: TEST flood\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerSamplingConcurrent tests that concurrent logging counts every line exactly once
+func TestLoggerSamplingConcurrent(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewLogger("TEST", WithWriter(&buf), WithSampling(time.Hour, 10, 0))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.Info("busy %d", i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if lines := strings.Count(buf.String(), "\n"); lines != 10 {
+		t.Errorf("Expected 10 lines, got %d", lines)
+	}
+}
+
+// TestLoggerSamplingClock tests that sampling windows follow the injected clock
+func TestLoggerSamplingClock(t *testing.T) {
+	var buf lockedBuffer
+	clock := &testClock{now: time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)}
+	logger := NewLogger("TEST", WithWriter(&buf), WithClock(clock), WithTimeFormat(TimeFormatNone),
+		WithSampling(time.Hour, 1, 0))
+
+	logger.Infoln("tick")
+	logger.Infoln("tick")
+	logger.Infoln("tick")
+	clock.Advance(time.Hour)
+	logger.Infoln("tick")
+
+	expected := "INFO: TEST tick\n" +
+		"INFO: TEST suppressed 2 similar messages sample=tick\n" +
+		"INFO: TEST tick\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerSamplingStdLog tests that lines written through StdLogger are sampled
+func TestLoggerSamplingStdLog(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithSampling(time.Hour, 1, 0))
+
+	std := logger.StdLogger(LogLevelError)
+	for i := 0; i < 5; i++ {
+		std.Print("http: TLS handshake error")
+	}
+
+	expected := "0001/01/01 00:00:00.000000 ERROR: TEST http: TLS handshake error\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerSamplingSummaryCaller tests that summary lines carry no caller
+func TestLoggerSamplingSummaryCaller(t *testing.T) {
+	var buf lockedBuffer
+	clock := &testClock{now: time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)}
+	logger := NewLogger("TEST", WithWriter(&buf), WithClock(clock), WithTimeFormat(TimeFormatNone),
+		WithCaller(0), WithSampling(time.Hour, 1, 0))
+
+	logger.Infoln("tick")
+	logger.Infoln("tick")
+	clock.Advance(time.Hour)
+	logger.Infoln("tick")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[1] != "INFO: TEST suppressed 1 similar messages sample=tick" {
+		t.Fatalf("Expected a summary line without caller, got: %q", buf.String())
+	}
+	if !strings.Contains(lines[2], "sampler_test.go:") {
+		t.Errorf("Expected regular lines to keep their caller, got: %q", lines[2])
+	}
+}
@@ -0,0 +1,134 @@
+package logger
+
+import (
+	"io"
+	"sync"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// ColorPolicy controls ANSI escape sequences in a sink's output
+type ColorPolicy int
+
+const (
+	// ColorAuto keeps ANSI escape sequences only when the writer supports
+	// them, see coloransi.Supported for the terminal and environment checks
+	ColorAuto ColorPolicy = iota
+	// ColorAlways keeps ANSI escape sequences
+	ColorAlways
+	// ColorNever strips ANSI escape sequences from every line
+	ColorNever
+)
+
+// Sink describes one output destination of a Logger
+type Sink struct {
+	Writer  io.Writer
+	Level   *LogLevel   // minimum level written to this sink, see LevelPtr; nil writes every level
+	Encoder Encoder     // nil uses TextEncoder
+	Color   ColorPolicy // ANSI handling for this sink
+}
+
+// LevelPtr returns a pointer to level, for Sink.Level
+func LevelPtr(level LogLevel) *LogLevel {
+	return &level
+}
+
+// sink is a configured output destination with its own write lock
+type sink struct {
+	writer  io.Writer
+	encoder Encoder
+	level   LogLevel
+	filter  bool // false for the default sink built from WithWriter and sinks without a level
+	ansi    bool // resolved from the color policy when the sink is built
+	mu      *sync.Mutex
+}
+
+// WithSink adds an output destination. Once any sink is configured, the
+// writer and encoder set via WithWriter and WithEncoder are no longer used.
+func WithSink(s Sink) LoggerOption {
+	return func(l *Logger) {
+		l.sinkCfgs = append(l.sinkCfgs, s)
+	}
+}
+
+// WithColor sets the color policy of the writer configured via WithWriter;
+// sinks added with WithSink carry their own policy
+func WithColor(policy ColorPolicy) LoggerOption {
+	return func(l *Logger) {
+		l.color = policy
+	}
+}
+
+// WithErrorHandler sets a callback for errors returned by sink writers.
+// A failing sink never prevents the other sinks from being written.
+func WithErrorHandler(fn func(error)) LoggerOption {
+	return func(l *Logger) {
+		l.onError = fn
+	}
+}
+
+// buildSinks turns the configured sinks, or the default writer, into sinks
+func (l *Logger) buildSinks() []*sink {
+	if len(l.sinkCfgs) == 0 {
+		return []*sink{{writer: l.writer, encoder: l.encoder, ansi: resolveColor(l.color, l.writer), mu: l.wmu}}
+	}
+
+	sinks := make([]*sink, 0, len(l.sinkCfgs))
+	for _, cfg := range l.sinkCfgs {
+		s := &sink{
+			writer:  cfg.Writer,
+			encoder: cfg.Encoder,
+			filter:  cfg.Level != nil,
+			ansi:    resolveColor(cfg.Color, cfg.Writer),
+			mu:      &sync.Mutex{},
+		}
+		if s.filter {
+			s.level = *cfg.Level
+		}
+		if s.encoder == nil {
+			s.encoder = TextEncoder{}
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks
+}
+
+// resolveColor reports whether a writer gets ANSI escape sequences under policy
+func resolveColor(policy ColorPolicy, w io.Writer) bool {
+	switch policy {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return coloransi.Supported(w)
+	}
+}
+
+// accepts reports whether the sink writes lines at level
+func (s *sink) accepts(level LogLevel) bool {
+	return !s.filter || level >= s.level
+}
+
+// applyColor enforces the sink's color policy on an encoded line
+func (s *sink) applyColor(line string) string {
+	if !s.ansi {
+		return coloransi.Strip(line)
+	}
+	return line
+}
+
+// write writes a line atomically with respect to other writes to this sink
+func (s *sink) write(line string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := io.WriteString(s.writer, line)
+	return err
+}
+
+// reportError passes a sink error to the error handler, if any
+func (l *Logger) reportError(err error) {
+	if err != nil && l.onError != nil {
+		l.onError(err)
+	}
+}
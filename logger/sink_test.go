@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// failingWriter always returns an error
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestLoggerSinks tests per-sink levels, encoders and color policies
+func TestLoggerSinks(t *testing.T) {
+	var terminal, plain, file bytes.Buffer
+	prefix := coloransi.Foreground(coloransi.Red, "app")
+	logger := NewLogger(prefix, WithZeroTime(),
+		WithSink(Sink{Writer: &terminal, Level: LevelPtr(LogLevelDebug), Color: ColorAlways}),
+		WithSink(Sink{Writer: &plain, Level: LevelPtr(LogLevelInfo), Color: ColorNever}),
+		WithSink(Sink{Writer: &file, Level: LevelPtr(LogLevelWarn), Encoder: JSONEncoder{}}),
+	)
+
+	logger.Debugln("debug")
+	logger.Warnln("warn")
+
+	expectedTerminal := "0001/01/01 00:00:00.000000 DEBUG: " + prefix + " debug\n" +
+		"0001/01/01 00:00:00.000000 WARN: " + prefix + " warn\n"
+	if output := terminal.String(); output != expectedTerminal {
+		t.Errorf("Terminal sink: expected %q, got: %q", expectedTerminal, output)
+	}
+
+	expectedPlain := "0001/01/01 00:00:00.000000 WARN: app warn\n"
+	if output := plain.String(); output != expectedPlain {
+		t.Errorf("Plain sink: expected %q, got: %q", expectedPlain, output)
+	}
+
+	expectedFile := `{"time":"0001-01-01T00:00:00Z","level":"WARN","prefix":"app","msg":"warn"}` + "\n"
+	if output := file.String(); output != expectedFile {
+		t.Errorf("File sink: expected %q, got: %q", expectedFile, output)
+	}
+}
+
+// TestLoggerColorAuto tests that ANSI escapes only reach writers that support them
+func TestLoggerColorAuto(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	os.Unsetenv("FORCE_COLOR")
+	t.Setenv("TERM", "xterm")
+	prefix := coloransi.Foreground(coloransi.Red, "app")
+
+	var buf bytes.Buffer
+	NewLogger(prefix, WithZeroTime(), WithWriter(&buf)).Infoln("not a terminal")
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 INFO: app not a terminal\n" {
+		t.Errorf("Expected stripped output, got: %q", output)
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+	buf.Reset()
+	NewLogger(prefix, WithZeroTime(), WithWriter(&buf)).Infoln("forced")
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 INFO: "+prefix+" forced\n" {
+		t.Errorf("Expected colored output with FORCE_COLOR, got: %q", output)
+	}
+}
+
+// TestLoggerSinkErrors tests that a failing sink is reported and does not stop the others
+func TestLoggerSinkErrors(t *testing.T) {
+	var buf, file bytes.Buffer
+	var errs []error
+	logger := NewLogger("TEST", WithZeroTime(),
+		WithSink(Sink{Writer: failingWriter{}}),
+		WithSink(Sink{Writer: &buf}),
+		WithSink(Sink{Writer: &file, Encoder: JSONEncoder{}}),
+		WithErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+
+	logger.Infoln("still written")
+	logger.SimplePrintLines([]string{"block"})
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST still written\nblock\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+	if len(errs) != 2 || errs[0].Error() != "disk full" {
+		t.Errorf("Expected two disk full errors, got %v", errs)
+	}
+	if output := file.String(); strings.Contains(output, "block") || strings.Count(output, "\n") != 1 {
+		t.Errorf("Expected raw lines to skip the JSON sink, got: %q", output)
+	}
+}
+
+// TestLoggerSinkTrace tests that sinks without a level accept TRACE lines
+func TestLoggerSinkTrace(t *testing.T) {
+	var all, debug bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithLevel(LogLevelTrace),
+		WithSink(Sink{Writer: &all}),
+		WithSink(Sink{Writer: &debug, Level: LevelPtr(LogLevelDebug)}),
+	)
+
+	logger.Traceln("trace")
+	logger.Debugln("debug")
+
+	expected := "0001/01/01 00:00:00.000000 TRACE: TEST trace\n" +
+		"0001/01/01 00:00:00.000000 DEBUG: TEST debug\n"
+	if output := all.String(); output != expected {
+		t.Errorf("Unset level sink: expected %q, got: %q", expected, output)
+	}
+	if output := debug.String(); output != "0001/01/01 00:00:00.000000 DEBUG: TEST debug\n" {
+		t.Errorf("DEBUG sink: unexpected output %q", output)
+	}
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync"
+)
+
+// levelWriter emits every newline terminated chunk written to it as a log line
+type levelWriter struct {
+	l     *Logger
+	level LogLevel
+	mu    sync.Mutex
+	buf   []byte
+}
+
+// Writer returns an io.WriteCloser that splits incoming bytes on newlines and
+// logs each line at the given level. Partial lines are buffered until a
+// newline arrives or the writer is closed.
+func (l *Logger) Writer(level LogLevel) io.WriteCloser {
+	return &levelWriter{l: l, level: level}
+}
+
+// StdLogger returns a standard library *log.Logger writing through l at the given level
+func (l *Logger) StdLogger(level LogLevel) *log.Logger {
+	// Skip the frames inside the log package so call sites point at its caller
+	return log.New(l.CallerSkip(2).Writer(level), "", 0)
+}
+
+// Write implements io.Writer
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	start := 0
+	for {
+		i := bytes.IndexByte(w.buf[start:], '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.buf[start : start+i])
+		start += i + 1
+	}
+	// Keep only the unterminated remainder
+	w.buf = w.buf[:copy(w.buf, w.buf[start:])]
+
+	return len(p), nil
+}
+
+// Close logs any buffered partial line
+func (w *levelWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.emit(w.buf)
+		w.buf = w.buf[:0]
+	}
+	return nil
+}
+
+// emit logs a single line without its line ending
+func (w *levelWriter) emit(line []byte) {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if w.l.GetLevel() > w.level {
+		return
+	}
+	if w.l.sampler != nil && !w.l.sampler.allow(w.level, string(line)) {
+		return
+	}
+	w.l.output(w.level, string(line), nil, nil)
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestLoggerWriter tests line splitting and buffering of partial lines
+func TestLoggerWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+	w := logger.Writer(LogLevelWarn)
+	w.Write([]byte("first line\nsecond "))
+	w.Write([]byte("line\r\nthird"))
+
+	expected := "0001/01/01 00:00:00.000000 WARN: TEST first line\n" +
+		"0001/01/01 00:00:00.000000 WARN: TEST second line\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	w.Close()
+	expected += "0001/01/01 00:00:00.000000 WARN: TEST third\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output after Close %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerWriterLevel tests that lines below the logger level are dropped
+func TestLoggerWriterLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithLevel(LogLevelInfo), WithZeroTime(), WithWriter(&buf))
+
+	logger.Writer(LogLevelDebug).Write([]byte("hidden\n"))
+	if output := buf.String(); output != "" {
+		t.Errorf("Expected no output, got: %q", output)
+	}
+}
+
+// TestLoggerStdLogger tests the standard library adapter
+func TestLoggerStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf))
+
+	std := logger.StdLogger(LogLevelError)
+	std.Printf("http: TLS handshake error from %s", "10.0.0.1")
+	std.Print("no newline")
+
+	expected := "0001/01/01 00:00:00.000000 ERROR: TEST http: TLS handshake error from 10.0.0.1\n" +
+		"0001/01/01 00:00:00.000000 ERROR: TEST no newline\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"strings"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// WithCorrelationTag renders the value of the first of keys found in a line's
+// fields, including those added by With and context keys, as a colored tag
+// before the message. The color is derived from the value, so related lines
+// always share it. Only the TextEncoder renders the tag; the field itself is
+// still written by every encoder.
+func WithCorrelationTag(keys ...string) LoggerOption {
+	return func(l *Logger) {
+		l.tagKeys = append([]string(nil), keys...)
+	}
+}
+
+// findTag returns the field matching the first of keys that is present
+func findTag(keys []string, fields []Field) Field {
+	for _, key := range keys {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if fields[i].Key == key {
+				return fields[i]
+			}
+		}
+	}
+	return Field{}
+}
+
+// tagColor hashes integer values directly and everything else by its text
+func tagColor(v interface{}) coloransi.ColorCode {
+	switch val := v.(type) {
+	case uint64:
+		return coloransi.ColorFromHash(val)
+	case uint:
+		return coloransi.ColorFromHash(uint64(val))
+	case uint32:
+		return coloransi.ColorFromHash(uint64(val))
+	case int64:
+		return coloransi.ColorFromHash(uint64(val))
+	case int:
+		return coloransi.ColorFromHash(uint64(val))
+	case int32:
+		return coloransi.ColorFromHash(uint64(val))
+	default:
+		return coloransi.ColorFromString(formatFieldValue(v))
+	}
+}
+
+// appendTag writes the colored correlation tag followed by a space
+func appendTag(sb *strings.Builder, tag Field) {
+	if tag.Key == "" {
+		return
+	}
+	sb.WriteString(coloransi.Tag(tagColor(tag.Value), formatFieldValue(tag.Value)))
+	sb.WriteByte(' ')
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Moonlight-Companies/gologger/coloransi"
+)
+
+// TestLoggerCorrelationTag tests that the tag is colored by its value
+func TestLoggerCorrelationTag(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithColor(ColorAlways), WithCorrelationTag("cookie", "request_id"))
+
+	logger.With("cookie", uint64(7)).Infoln("scanned")
+	logger.InfoKV("routed", "request_id", "r-1", "cookie", uint64(8))
+	logger.Infoln("untagged")
+
+	tag7 := coloransi.Tag(coloransi.ColorFromHash(7), "7")
+	tag8 := coloransi.Tag(coloransi.ColorFromHash(8), "8")
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST " + tag7 + " scanned cookie=7\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST " + tag8 + " routed request_id=r-1 cookie=8\n" +
+		"0001/01/01 00:00:00.000000 INFO: TEST untagged\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// TestLoggerCorrelationTagContext tests tags taken from registered context keys
+func TestLoggerCorrelationTagContext(t *testing.T) {
+	RegisterContextKey(testRequestIDKey{}, "request_id")
+	t.Cleanup(func() { UnregisterContextKey(testRequestIDKey{}) })
+
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithColor(ColorAlways), WithCorrelationTag("request_id"))
+
+	ctx := context.WithValue(context.Background(), testRequestIDKey{}, "r-42")
+	logger.InfoCtx(ctx, "first")
+	logger.InfoCtx(ctx, "second")
+
+	tag := coloransi.Tag(coloransi.ColorFromString("r-42"), "r-42")
+	if count := strings.Count(buf.String(), tag+" "); count != 2 {
+		t.Errorf("Expected the same tag on both lines, got: %q", buf.String())
+	}
+}
+
+// TestLoggerCorrelationTagEncoders tests that non-text encoders only write the field
+func TestLoggerCorrelationTagEncoders(t *testing.T) {
+	for _, enc := range []Encoder{JSONEncoder{}, LogfmtEncoder{}} {
+		var buf bytes.Buffer
+		logger := NewLogger("TEST", WithWriter(&buf), WithEncoder(enc), WithColor(ColorAlways), WithCorrelationTag("cookie"))
+
+		logger.InfoKV("scanned", "cookie", 7)
+		if output := buf.String(); strings.Contains(output, "\033") || !strings.Contains(output, "cookie") {
+			t.Errorf("%T: expected plain cookie field, got: %q", enc, output)
+		}
+	}
+}
+
+// TestLoggerCorrelationTagNoColor tests that the tag is omitted without color
+func TestLoggerCorrelationTagNoColor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger("TEST", WithZeroTime(), WithWriter(&buf), WithColor(ColorNever), WithCorrelationTag("cookie"))
+
+	logger.InfoKV("scanned", "cookie", 7)
+	if output := buf.String(); output != "0001/01/01 00:00:00.000000 INFO: TEST scanned cookie=7\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+}
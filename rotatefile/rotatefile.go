@@ -0,0 +1,410 @@
+// Package rotatefile provides an io.Writer that writes to a file and rotates it
+// by size and/or wall-clock interval, for use with logger.WithWriter.
+package rotatefile
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// backupTimeFormat is embedded in backup names, free of characters Windows rejects.
+// Rotations within the same millisecond get a "-1", "-2"... counter after it.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+const compressSuffix = ".gz"
+
+// Writer is a rotating file io.Writer. It is safe for concurrent use.
+type Writer struct {
+	filename   string
+	maxSize    int64
+	interval   time.Duration
+	maxBackups int
+	maxAge     time.Duration
+	compress   bool
+	onSIGHUP   bool
+	now        func() time.Time
+
+	mu         sync.Mutex
+	file       *os.File
+	closed     bool
+	size       int64
+	nextRotate time.Time
+
+	millMu  sync.Mutex     // serializes compression and cleanup
+	millWG  sync.WaitGroup // outstanding compression and cleanup runs
+	signals chan os.Signal
+	stop    chan struct{}
+}
+
+// Option configures a Writer
+type Option func(*Writer)
+
+// WithMaxSize rotates the file before a write would grow it beyond maxBytes
+func WithMaxSize(maxBytes int64) Option {
+	return func(w *Writer) {
+		w.maxSize = maxBytes
+	}
+}
+
+// WithInterval rotates the file whenever the wall clock crosses a multiple of d,
+// e.g. every hour on the hour. Multiples are computed from the zero time in UTC.
+func WithInterval(d time.Duration) Option {
+	return func(w *Writer) {
+		w.interval = d
+	}
+}
+
+// WithMaxBackups keeps at most n rotated files, deleting the oldest
+func WithMaxBackups(n int) Option {
+	return func(w *Writer) {
+		w.maxBackups = n
+	}
+}
+
+// WithMaxAge deletes rotated files older than d
+func WithMaxAge(d time.Duration) Option {
+	return func(w *Writer) {
+		w.maxAge = d
+	}
+}
+
+// WithCompress gzip-compresses rotated files in the background
+func WithCompress(compress bool) Option {
+	return func(w *Writer) {
+		w.compress = compress
+	}
+}
+
+// WithReopenOnSIGHUP reopens the file whenever the process receives SIGHUP,
+// for use with external tools such as logrotate that move the file away
+func WithReopenOnSIGHUP() Option {
+	return func(w *Writer) {
+		w.onSIGHUP = true
+	}
+}
+
+// New opens filename for appending, creating it and its directory if needed
+func New(filename string, options ...Option) (*Writer, error) {
+	w := &Writer{
+		filename: filename,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	if w.onSIGHUP {
+		w.signals = make(chan os.Signal, 1)
+		signal.Notify(w.signals, syscall.SIGHUP)
+		go w.watchSignals()
+	}
+
+	return w, nil
+}
+
+// Write implements io.Writer, rotating first when the size or interval limit is reached
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Rotate forces a rotation of the current file
+func (w *Writer) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return os.ErrClosed
+	}
+	return w.rotate()
+}
+
+// Reopen closes and reopens the file at the same path without creating a backup
+func (w *Writer) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return os.ErrClosed
+	}
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// Sync commits the current file contents to stable storage
+func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Close closes the file and waits for background compression and cleanup to finish.
+// Later writes return os.ErrClosed.
+func (w *Writer) Close() error {
+	if w.signals != nil {
+		signal.Stop(w.signals)
+	}
+
+	w.mu.Lock()
+	select {
+	case <-w.stop:
+	default:
+		close(w.stop)
+	}
+	w.closed = true
+	err := w.closeFile()
+	w.mu.Unlock()
+
+	w.millWG.Wait()
+	return err
+}
+
+func (w *Writer) watchSignals() {
+	for {
+		select {
+		case <-w.signals:
+			w.Reopen()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// shouldRotate reports whether writing n more bytes requires a rotation first
+func (w *Writer) shouldRotate(n int64) bool {
+	if w.maxSize > 0 && w.size > 0 && w.size+n > w.maxSize {
+		return true
+	}
+	return w.interval > 0 && !w.now().Before(w.nextRotate)
+}
+
+// open opens the file for appending and resets the rotation state
+func (w *Writer) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.filename), 0755); err != nil {
+		return fmt.Errorf("rotatefile: create directory: %w", err)
+	}
+
+	f, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("rotatefile: open: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("rotatefile: stat: %w", err)
+	}
+
+	w.file = f
+	w.size = info.Size()
+	if w.interval > 0 {
+		w.nextRotate = w.now().Truncate(w.interval).Add(w.interval)
+	}
+	return nil
+}
+
+func (w *Writer) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// rotate moves the current file to a timestamped backup and opens a fresh one
+func (w *Writer) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+
+	backup := w.backupName(w.now())
+	if err := os.Rename(w.filename, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotatefile: rename: %w", err)
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.millWG.Add(1)
+	go w.mill(backup, w.now().Add(-w.maxAge))
+	return nil
+}
+
+// mill compresses a fresh backup and enforces the backup count and age limits,
+// deleting backups rotated before cutoff when a max age is set
+func (w *Writer) mill(backup string, cutoff time.Time) {
+	defer w.millWG.Done()
+
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	if w.compress {
+		if err := compressFile(backup); err == nil {
+			os.Remove(backup)
+		}
+	}
+
+	if w.maxBackups <= 0 && w.maxAge <= 0 {
+		return
+	}
+
+	backups := w.backups()
+	for i, b := range backups {
+		if (w.maxBackups > 0 && i >= w.maxBackups) || (w.maxAge > 0 && b.t.Before(cutoff)) {
+			os.Remove(b.path)
+		}
+	}
+}
+
+// backupName returns an unused backup path for a rotation at t: name-<time>.ext,
+// or name-<time>-<n>.ext when earlier rotations in the same millisecond took it
+func (w *Writer) backupName(t time.Time) string {
+	dir, base, ext := w.nameParts()
+	stamp := base + "-" + t.In(time.Local).Format(backupTimeFormat)
+	for n := 0; ; n++ {
+		name := stamp
+		if n > 0 {
+			name += "-" + strconv.Itoa(n)
+		}
+		path := filepath.Join(dir, name+ext)
+		if !exists(path) && !exists(path+compressSuffix) {
+			return path
+		}
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+func (w *Writer) nameParts() (dir, base, ext string) {
+	dir = filepath.Dir(w.filename)
+	name := filepath.Base(w.filename)
+	ext = filepath.Ext(name)
+	return dir, strings.TrimSuffix(name, ext), ext
+}
+
+type backupFile struct {
+	path string
+	t    time.Time
+	n    int // counter of rotations within the same millisecond
+}
+
+// backups lists rotated files, newest first
+func (w *Writer) backups() []backupFile {
+	dir, base, ext := w.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var result []backupFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, base+"-") {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, base+"-")
+		stamp = strings.TrimSuffix(stamp, compressSuffix)
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		stamp = strings.TrimSuffix(stamp, ext)
+		if len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, stamp[:len(backupTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+		n := 0
+		if counter := stamp[len(backupTimeFormat):]; counter != "" {
+			if !strings.HasPrefix(counter, "-") {
+				continue
+			}
+			if n, err = strconv.Atoi(counter[1:]); err != nil || n <= 0 {
+				continue
+			}
+		}
+		result = append(result, backupFile{path: filepath.Join(dir, name), t: t, n: n})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].t.Equal(result[j].t) {
+			return result[i].t.After(result[j].t)
+		}
+		return result[i].n > result[j].n
+	})
+	return result
+}
+
+// compressFile writes a gzip copy of path to path.gz
+func compressFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := dst.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(path + compressSuffix)
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		return err
+	}
+	return gz.Close()
+}
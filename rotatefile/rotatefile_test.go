@@ -0,0 +1,223 @@
+package rotatefile
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Moonlight-Companies/gologger/logger"
+)
+
+// fakeClock returns a controllable time source for the writer
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func newTestWriter(t *testing.T, clock *fakeClock, options ...Option) (*Writer, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	options = append([]Option{func(w *Writer) { w.now = clock.now }}, options...)
+	w, err := New(path, options...)
+	if err != nil {
+		t.Fatalf("New returned %v", err)
+	}
+	t.Cleanup(func() { w.Close() })
+	return w, path
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRotateOnSize(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	w, path := newTestWriter(t, clock, WithMaxSize(10))
+
+	w.Write([]byte("12345\n"))
+	w.Write([]byte("6789\n")) // 11 bytes would exceed the limit
+	clock.t = clock.t.Add(time.Second)
+	w.Write([]byte("abcdef\n"))
+	w.Close()
+
+	names := listDir(t, filepath.Dir(path))
+	expected := []string{"app-2024-03-01T10-00-00.000.log", "app-2024-03-01T10-00-01.000.log", "app.log"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Files %v, want %v", names, expected)
+	}
+	if content := readFile(t, path); content != "abcdef\n" {
+		t.Errorf("Current file %q, want %q", content, "abcdef\n")
+	}
+	if content := readFile(t, filepath.Join(filepath.Dir(path), expected[0])); content != "12345\n" {
+		t.Errorf("First backup %q, want %q", content, "12345\n")
+	}
+}
+
+func TestRotateSameMillisecond(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	w, path := newTestWriter(t, clock, WithMaxSize(10))
+
+	for i := 0; i < 100; i++ {
+		if _, err := fmt.Fprintf(w, "line %02d\n", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+
+	dir := filepath.Dir(path)
+	var lines []string
+	for _, name := range listDir(t, dir) {
+		lines = append(lines, strings.Split(strings.TrimSpace(readFile(t, filepath.Join(dir, name))), "\n")...)
+	}
+	sort.Strings(lines)
+	if len(lines) != 100 {
+		t.Fatalf("Expected all 100 lines on disk, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if want := fmt.Sprintf("line %02d", i); line != want {
+			t.Fatalf("Line %d is %q, want %q", i, line, want)
+		}
+	}
+
+	backups := w.backups()
+	if len(backups) != 99 || backups[0].n != 98 || backups[98].n != 0 {
+		t.Errorf("Expected 99 backups ordered by counter, newest first, got %d", len(backups))
+	}
+	if content := readFile(t, backups[0].path); content != "line 98\n" {
+		t.Errorf("Newest backup %q, want %q", content, "line 98\n")
+	}
+}
+
+func TestWriteAfterClose(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	w, path := newTestWriter(t, clock)
+
+	w.Write([]byte("before\n"))
+	w.Close()
+
+	if _, err := w.Write([]byte("after\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write after Close returned %v, want %v", err, os.ErrClosed)
+	}
+	if err := w.Rotate(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Rotate after Close returned %v, want %v", err, os.ErrClosed)
+	}
+	if content := readFile(t, path); content != "before\n" {
+		t.Errorf("File %q, want %q", content, "before\n")
+	}
+}
+
+func TestRotateOnInterval(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 3, 1, 10, 59, 0, 0, time.UTC)}
+	w, path := newTestWriter(t, clock, WithInterval(time.Hour))
+
+	w.Write([]byte("before\n"))
+	clock.t = clock.t.Add(30 * time.Second)
+	w.Write([]byte("still before\n"))
+	clock.t = clock.t.Add(30 * time.Second)
+	w.Write([]byte("after\n"))
+	w.Close()
+
+	if content := readFile(t, path); content != "after\n" {
+		t.Errorf("Current file %q, want %q", content, "after\n")
+	}
+	if names := listDir(t, filepath.Dir(path)); len(names) != 2 {
+		t.Errorf("Expected one backup, got files %v", names)
+	}
+}
+
+func TestMaxBackupsAndCompress(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	w, path := newTestWriter(t, clock, WithMaxBackups(2), WithCompress(true))
+
+	for i := 0; i < 4; i++ {
+		w.Write([]byte("line\n"))
+		clock.t = clock.t.Add(time.Minute)
+		if err := w.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+
+	names := listDir(t, filepath.Dir(path))
+	expected := []string{"app-2024-03-01T10-03-00.000.log.gz", "app-2024-03-01T10-04-00.000.log.gz", "app.log"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Files %v, want %v", names, expected)
+	}
+
+	f, err := os.Open(filepath.Join(filepath.Dir(path), expected[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(gz)
+	if string(data) != "line\n" {
+		t.Errorf("Decompressed backup %q, want %q", data, "line\n")
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	w, path := newTestWriter(t, clock, WithMaxAge(24*time.Hour))
+
+	w.Write([]byte("old\n"))
+	w.Rotate()
+	clock.t = clock.t.Add(48 * time.Hour)
+	w.Write([]byte("new\n"))
+	w.Rotate()
+	w.Close()
+
+	names := listDir(t, filepath.Dir(path))
+	expected := []string{"app-2024-03-03T10-00-00.000.log", "app.log"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Files %v, want %v", names, expected)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	w, path := newTestWriter(t, clock, WithMaxSize(60))
+
+	l := logger.NewLogger("TEST", logger.WithZeroTime(), logger.WithWriter(w))
+	l.Infoln("first message")
+	l.Infoln("second message")
+	w.Close()
+
+	if content := readFile(t, path); content != "0001/01/01 00:00:00.000000 INFO: TEST second message\n" {
+		t.Errorf("Current file %q", content)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build unix
+
+package rotatefile
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReopenOnSIGHUP(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	w, path := newTestWriter(t, clock, WithReopenOnSIGHUP())
+
+	w.Write([]byte("before\n"))
+	moved := path + ".1"
+	if err := os.Rename(path, moved); err != nil {
+		t.Fatal(err)
+	}
+
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("File was not reopened after SIGHUP")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	w.Write([]byte("after\n"))
+	w.Close()
+	if content := readFile(t, path); content != "after\n" {
+		t.Errorf("Reopened file %q, want %q", content, "after\n")
+	}
+	if content := readFile(t, moved); content != "before\n" {
+		t.Errorf("Moved file %q, want %q", content, "before\n")
+	}
+}
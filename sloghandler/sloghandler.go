@@ -0,0 +1,122 @@
+// Package sloghandler connects the standard log/slog API to gologger.
+package sloghandler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Moonlight-Companies/gologger/logger"
+)
+
+// Handler is an slog.Handler that writes records through a *logger.Logger,
+// so slog output looks identical to the logger's own lines and respects its level.
+type Handler struct {
+	l      *logger.Logger
+	fields []logger.Field // attributes added via WithAttrs, already qualified
+	group  string         // dotted key prefix for attributes, set via WithGroup
+}
+
+// New returns a Handler routing records into l. Lines use the record's time
+// and report the call site recorded by slog.
+func New(l *logger.Logger) *Handler {
+	return &Handler{l: l}
+}
+
+// NewSlog wraps l as an *slog.Logger
+func NewSlog(l *logger.Logger) *slog.Logger {
+	return slog.New(New(l))
+}
+
+// ToLogLevel maps an slog level onto the nearest LogLevel at or below it
+func ToLogLevel(level slog.Level) logger.LogLevel {
+	switch {
+	case level < slog.LevelDebug:
+		return logger.LogLevelTrace
+	case level < slog.LevelInfo:
+		return logger.LogLevelDebug
+	case level < slog.LevelWarn:
+		return logger.LogLevelInfo
+	case level < slog.LevelError:
+		return logger.LogLevelWarn
+	default:
+		return logger.LogLevelError
+	}
+}
+
+// FromLogLevel maps a LogLevel onto the matching slog level
+func FromLogLevel(level logger.LogLevel) slog.Level {
+	switch {
+	case level < logger.LogLevelDebug:
+		return slog.LevelDebug - 4
+	case level == logger.LogLevelDebug:
+		return slog.LevelDebug
+	case level == logger.LogLevelInfo:
+		return slog.LevelInfo
+	case level == logger.LogLevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// Enabled implements slog.Handler
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.GetLevel() <= ToLogLevel(level)
+}
+
+// Handle implements slog.Handler
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	ctxFields := logger.ContextFields(ctx)
+	fields := make([]logger.Field, 0, len(h.fields)+len(ctxFields)+r.NumAttrs())
+	fields = append(fields, h.fields...)
+	fields = append(fields, ctxFields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, h.group, a)
+		return true
+	})
+
+	h.l.LogRecord(ToLogLevel(r.Level), r.Time, r.PC, r.Message, fields)
+	return nil
+}
+
+// WithAttrs implements slog.Handler
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make([]logger.Field, 0, len(h.fields)+len(attrs))
+	fields = append(fields, h.fields...)
+	for _, a := range attrs {
+		fields = appendAttr(fields, h.group, a)
+	}
+	return &Handler{l: h.l, fields: fields, group: h.group}
+}
+
+// WithGroup implements slog.Handler
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{l: h.l, fields: h.fields, group: h.group + name + "."}
+}
+
+// appendAttr flattens an attribute into fields, qualifying keys with their groups
+func appendAttr(fields []logger.Field, group string, a slog.Attr) []logger.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			group = group + a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, group, ga)
+		}
+		return fields
+	}
+
+	return append(fields, logger.Field{Key: group + a.Key, Value: a.Value.Any()})
+}
@@ -0,0 +1,149 @@
+package sloghandler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Moonlight-Companies/gologger/logger"
+)
+
+func TestSlogMatchesLogger(t *testing.T) {
+	var direct, viaSlog bytes.Buffer
+	l1 := logger.NewLogger("TEST", logger.WithZeroTime(), logger.WithWriter(&direct))
+	l2 := logger.NewLogger("TEST", logger.WithZeroTime(), logger.WithWriter(&viaSlog))
+
+	l1.InfoKV("hello", "user", "bob", "n", 3)
+	NewSlog(l2).Info("hello", "user", "bob", "n", 3)
+
+	if direct.String() != viaSlog.String() {
+		t.Errorf("Expected slog output %q to match logger output %q", viaSlog.String(), direct.String())
+	}
+}
+
+func TestSlogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewLogger("TEST", logger.WithLevel(logger.LogLevelWarn), logger.WithZeroTime(), logger.WithWriter(&buf))
+	s := NewSlog(l)
+
+	s.Debug("debug")
+	s.Info("info")
+	s.Warn("warn")
+	s.Error("error")
+
+	expected := "0001/01/01 00:00:00.000000 WARN: TEST warn\n" +
+		"0001/01/01 00:00:00.000000 ERROR: TEST error\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	l.SetLevel(logger.LogLevelDebug)
+	if !s.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected debug to be enabled after SetLevel")
+	}
+}
+
+func TestSlogAttrsAndGroups(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewLogger("TEST", logger.WithZeroTime(), logger.WithWriter(&buf))
+
+	s := NewSlog(l).With("svc", "plc").WithGroup("req").With("id", 7)
+	s.Info("done", "took", time.Second, slog.Group("db", "rows", 2), slog.Group("", "inline", true), slog.Attr{})
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST done svc=plc req.id=7 req.took=1s req.db.rows=2 req.inline=true\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+type requestIDKey struct{}
+
+func TestSlogContextFields(t *testing.T) {
+	logger.RegisterContextKey(requestIDKey{}, "request_id")
+	t.Cleanup(func() { logger.UnregisterContextKey(requestIDKey{}) })
+
+	var buf bytes.Buffer
+	l := logger.NewLogger("TEST", logger.WithZeroTime(), logger.WithWriter(&buf))
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "r-1")
+	NewSlog(l).With("svc", "plc").InfoContext(ctx, "done", "n", 2)
+
+	expected := "0001/01/01 00:00:00.000000 INFO: TEST done svc=plc request_id=r-1 n=2\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+func TestLevelMapping(t *testing.T) {
+	testCases := []struct {
+		slogLevel slog.Level
+		level     logger.LogLevel
+	}{
+		{slog.LevelDebug - 4, logger.LogLevelTrace},
+		{slog.LevelDebug - 1, logger.LogLevelTrace},
+		{slog.LevelDebug, logger.LogLevelDebug},
+		{slog.LevelInfo, logger.LogLevelInfo},
+		{slog.LevelInfo + 2, logger.LogLevelInfo},
+		{slog.LevelWarn, logger.LogLevelWarn},
+		{slog.LevelError, logger.LogLevelError},
+		{slog.LevelError + 4, logger.LogLevelError},
+	}
+
+	for _, tc := range testCases {
+		if level := ToLogLevel(tc.slogLevel); level != tc.level {
+			t.Errorf("ToLogLevel(%s) = %s, want %s", tc.slogLevel, level, tc.level)
+		}
+	}
+
+	for _, level := range []logger.LogLevel{logger.LogLevelTrace, logger.LogLevelDebug, logger.LogLevelInfo, logger.LogLevelWarn, logger.LogLevelError} {
+		if back := ToLogLevel(FromLogLevel(level)); back != level {
+			t.Errorf("Round trip of %s gave %s", level, back)
+		}
+	}
+}
+
+func TestSlogCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewLogger("TEST", logger.WithZeroTime(), logger.WithWriter(&buf), logger.WithCaller(0))
+
+	NewSlog(l).Info("msg")
+	_, _, line, _ := runtime.Caller(0)
+
+	expected := fmt.Sprintf("0001/01/01 00:00:00.000000 INFO: TEST sloghandler_test.go:%d msg\n", line-1)
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+}
+
+// logHelper logs through slog's Handler directly with the caller's PC, as
+// wrapper libraries do
+func logHelper(s *slog.Logger, msg string) {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	r := slog.NewRecord(time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC), slog.LevelWarn, msg, pcs[0])
+	_ = s.Handler().Handle(context.Background(), r)
+}
+
+func TestSlogRecordTimeAndPC(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewLogger("TEST", logger.WithWriter(&buf), logger.WithCaller(0), logger.WithLocation(time.UTC))
+
+	logHelper(NewSlog(l), "wrapped")
+	_, _, line, _ := runtime.Caller(0)
+
+	expected := fmt.Sprintf("2024/03/04 05:06:07.000000 WARN: TEST sloghandler_test.go:%d wrapped\n", line-1)
+	if output := buf.String(); output != expected {
+		t.Errorf("Expected output %q, got: %q", expected, output)
+	}
+
+	buf.Reset()
+	_ = New(l).Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "no pc", 0))
+	if output := buf.String(); !strings.HasSuffix(output, " INFO: TEST no pc\n") {
+		t.Errorf("Expected a line without caller, got: %q", output)
+	}
+}